@@ -3,14 +3,15 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/gorilla/mux"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -28,24 +29,61 @@ type Server struct {
 
 // ExperimentRequest represents a request to create a new experiment
 type ExperimentRequest struct {
-	Name          string            `json:"name"`
-	Namespace     string            `json:"namespace"`
-	TargetName    string            `json:"targetName"`
-	TargetKind    string            `json:"targetKind"`
+	Name           string            `json:"name"`
+	Namespace      string            `json:"namespace"`
+	TargetName     string            `json:"targetName"`
+	TargetKind     string            `json:"targetKind"`
 	ExperimentType string            `json:"experimentType"`
-	Duration      string            `json:"duration"`
-	Parameters    map[string]string `json:"parameters"`
+	Duration       string            `json:"duration"`
+	Parameters     map[string]string `json:"parameters"`
+	Mode           string            `json:"mode,omitempty"`
+	Value          string            `json:"value,omitempty"`
+}
+
+// AbortRequest represents a request to abort a running experiment
+type AbortRequest struct {
+	Reason string `json:"reason"`
+	User   string `json:"user"`
 }
 
 // ExperimentResponse represents an experiment response
 type ExperimentResponse struct {
-	Name          string            `json:"name"`
-	Namespace     string            `json:"namespace"`
-	ExperimentType string            `json:"experimentType"`
-	Status        string            `json:"status"`
-	StartTime     *metav1.Time      `json:"startTime,omitempty"`
-	EndTime       *metav1.Time      `json:"endTime,omitempty"`
-	Message       string            `json:"message,omitempty"`
+	Name           string                       `json:"name"`
+	Namespace      string                       `json:"namespace"`
+	ExperimentType string                       `json:"experimentType"`
+	Status         string                       `json:"status"`
+	StartTime      *metav1.Time                 `json:"startTime,omitempty"`
+	EndTime        *metav1.Time                 `json:"endTime,omitempty"`
+	Message        string                       `json:"message,omitempty"`
+	Paused         bool                         `json:"paused,omitempty"`
+	AbortedBy      string                       `json:"abortedBy,omitempty"`
+	AbortReason    string                       `json:"abortReason,omitempty"`
+	TargetKind     string                       `json:"targetKind,omitempty"`
+	TargetName     string                       `json:"targetName,omitempty"`
+	Targets        []chaosv1alpha1.TargetStatus `json:"targets,omitempty"`
+	Result         string                       `json:"result,omitempty"`
+	Probes         []chaosv1alpha1.ProbeStatus  `json:"probes,omitempty"`
+}
+
+// newExperimentResponse converts an experiment into its response format
+func newExperimentResponse(experiment *chaosv1alpha1.ChaosExperiment) ExperimentResponse {
+	return ExperimentResponse{
+		Name:           experiment.Name,
+		Namespace:      experiment.Namespace,
+		ExperimentType: experiment.Spec.ExperimentType,
+		Status:         experiment.Status.Phase,
+		StartTime:      experiment.Status.StartTime,
+		EndTime:        experiment.Status.EndTime,
+		Message:        experiment.Status.Message,
+		Paused:         experiment.Spec.Paused,
+		AbortedBy:      experiment.Status.AbortedBy,
+		AbortReason:    experiment.Status.AbortReason,
+		TargetKind:     experiment.Spec.Target.Kind,
+		TargetName:     experiment.Spec.Target.Name,
+		Targets:        experiment.Status.Targets,
+		Result:         experiment.Status.Result,
+		Probes:         experiment.Status.Probes,
+	}
 }
 
 func main() {
@@ -91,6 +129,9 @@ func main() {
 	r.HandleFunc("/api/experiments", server.createExperiment).Methods("POST")
 	r.HandleFunc("/api/experiments/{namespace}/{name}", server.getExperiment).Methods("GET")
 	r.HandleFunc("/api/experiments/{namespace}/{name}", server.deleteExperiment).Methods("DELETE")
+	r.HandleFunc("/api/experiments/{namespace}/{name}/abort", server.abortExperiment).Methods("POST")
+	r.HandleFunc("/api/experiments/{namespace}/{name}/pause", server.pauseExperiment).Methods("POST")
+	r.HandleFunc("/api/experiments/{namespace}/{name}/resume", server.resumeExperiment).Methods("POST")
 
 	// Serve static files for the React app
 	r.PathPrefix("/").Handler(http.FileServer(http.Dir("./dashboard/build")))
@@ -116,16 +157,8 @@ func (s *Server) listExperiments(w http.ResponseWriter, r *http.Request) {
 
 	// Convert to response format
 	var response []ExperimentResponse
-	for _, exp := range experiments.Items {
-		response = append(response, ExperimentResponse{
-			Name:          exp.Name,
-			Namespace:     exp.Namespace,
-			ExperimentType: exp.Spec.ExperimentType,
-			Status:        exp.Status.Phase,
-			StartTime:     exp.Status.StartTime,
-			EndTime:       exp.Status.EndTime,
-			Message:       exp.Status.Message,
-		})
+	for i := range experiments.Items {
+		response = append(response, newExperimentResponse(&experiments.Items[i]))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -144,15 +177,7 @@ func (s *Server) getExperiment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := ExperimentResponse{
-		Name:          experiment.Name,
-		Namespace:     experiment.Namespace,
-		ExperimentType: experiment.Spec.ExperimentType,
-		Status:        experiment.Status.Phase,
-		StartTime:     experiment.Status.StartTime,
-		EndTime:       experiment.Status.EndTime,
-		Message:       experiment.Status.Message,
-	}
+	response := newExperimentResponse(experiment)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -174,14 +199,16 @@ func (s *Server) createExperiment(w http.ResponseWriter, r *http.Request) {
 		},
 		Spec: chaosv1alpha1.ChaosExperimentSpec{
 			Target: chaosv1alpha1.TargetResource{
-				APIVersion: "v1",
+				APIVersion: targetAPIVersion(req.TargetKind),
 				Kind:       req.TargetKind,
 				Name:       req.TargetName,
 				Namespace:  req.Namespace,
 			},
 			ExperimentType: req.ExperimentType,
-			Duration:      req.Duration,
-			Parameters:    req.Parameters,
+			Duration:       req.Duration,
+			Parameters:     req.Parameters,
+			Mode:           req.Mode,
+			Value:          req.Value,
 		},
 	}
 
@@ -191,21 +218,23 @@ func (s *Server) createExperiment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := ExperimentResponse{
-		Name:          result.Name,
-		Namespace:     result.Namespace,
-		ExperimentType: result.Spec.ExperimentType,
-		Status:        result.Status.Phase,
-		StartTime:     result.Status.StartTime,
-		EndTime:       result.Status.EndTime,
-		Message:       result.Status.Message,
-	}
+	response := newExperimentResponse(result)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
 }
 
+// targetAPIVersion returns the API version of a target kind
+func targetAPIVersion(kind string) string {
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		return "apps/v1"
+	default:
+		return "v1"
+	}
+}
+
 // deleteExperiment deletes a chaos experiment
 func (s *Server) deleteExperiment(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -220,3 +249,75 @@ func (s *Server) deleteExperiment(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// abortExperiment stops a running experiment early and records who aborted it and why
+func (s *Server) abortExperiment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	name := vars["name"]
+
+	var req AbortRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Prefer the user authenticated by a fronting proxy over the one in the body
+	user := r.Header.Get("X-Remote-User")
+	if user == "" {
+		user = req.User
+	}
+
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				chaosv1alpha1.AnnotationAbort:       "true",
+				chaosv1alpha1.AnnotationAbortReason: req.Reason,
+				chaosv1alpha1.AnnotationAbortedBy:   user,
+			},
+		},
+	}
+	s.patchExperiment(w, r, namespace, name, patch)
+}
+
+// pauseExperiment pauses a running experiment, recovering its targets until it is resumed
+func (s *Server) pauseExperiment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"paused": true,
+		},
+	}
+	s.patchExperiment(w, r, vars["namespace"], vars["name"], patch)
+}
+
+// resumeExperiment resumes a paused experiment
+func (s *Server) resumeExperiment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"paused": false,
+		},
+	}
+	s.patchExperiment(w, r, vars["namespace"], vars["name"], patch)
+}
+
+// patchExperiment applies a merge patch to an experiment and writes the result
+func (s *Server) patchExperiment(w http.ResponseWriter, r *http.Request, namespace, name string, patch map[string]interface{}) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result, err := s.ChaosClient.ChaosV1alpha1().ChaosExperiments(namespace).Patch(r.Context(), name, types.MergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := newExperimentResponse(result)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
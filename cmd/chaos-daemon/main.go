@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/daemon"
+	"k8s.io/klog/v2"
+)
+
+var (
+	port       int
+	tokenFile  string
+	certFile   string
+	keyFile    string
+	procRoot   string
+	cgroupRoot string
+)
+
+func main() {
+	klog.InitFlags(nil)
+	flag.Parse()
+
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		klog.Fatalf("Error reading token: %s", err.Error())
+	}
+	if strings.TrimSpace(string(token)) == "" {
+		klog.Fatalf("Token file %s is empty", tokenFile)
+	}
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: daemon.NewServer(strings.TrimSpace(string(token)), procRoot, cgroupRoot),
+	}
+
+	go func() {
+		klog.Infof("Starting chaos daemon on port %d", port)
+		if err := server.ListenAndServeTLS(certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.Fatalf("Error running chaos daemon: %s", err.Error())
+		}
+	}()
+
+	// Wait for a shutdown signal and let in-flight commands finish
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		klog.Errorf("Error shutting down chaos daemon: %s", err.Error())
+	}
+}
+
+func init() {
+	flag.IntVar(&port, "port", daemon.DefaultPort, "The port the chaos daemon listens on.")
+	flag.StringVar(&tokenFile, "token-file", "/etc/chaos-daemon/token", "Path to the file holding the token clients must present.")
+	flag.StringVar(&certFile, "tls-cert-file", "/etc/chaos-daemon-tls/tls.crt", "Path to the TLS certificate of the chaos daemon.")
+	flag.StringVar(&keyFile, "tls-private-key-file", "/etc/chaos-daemon-tls/tls.key", "Path to the TLS private key of the chaos daemon.")
+	flag.StringVar(&procRoot, "proc-root", "/proc", "Path to the proc filesystem of the host PID namespace.")
+	flag.StringVar(&cgroupRoot, "cgroup-root", "/host/sys/fs/cgroup", "Path to the root of the cgroup hierarchy of the host.")
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	chaosv1alpha1 "github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/daemon"
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
 	"github.com/chaos-engineering/controller/pkg/controller"
-	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
 	clientset "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned"
 	informers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
@@ -18,8 +27,16 @@ import (
 )
 
 var (
-	masterURL  string
-	kubeconfig string
+	masterURL       string
+	kubeconfig      string
+	chaosToolsImage string
+
+	chaosDaemonNamespace string
+	chaosDaemonSelector  string
+	chaosDaemonPort      int
+	chaosDaemonTokenFile string
+	chaosDaemonCAFile    string
+	chaosDaemonServer    string
 )
 
 func main() {
@@ -44,13 +61,55 @@ func main() {
 		klog.Fatalf("Error building chaos clientset: %s", err.Error())
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("Error building dynamic client: %s", err.Error())
+	}
+
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, time.Second*30)
 	chaosInformerFactory := informers.NewSharedInformerFactory(chaosClient, time.Second*30)
 
+	scheduleController := controller.NewScheduleController(
+		chaosClient,
+		chaosInformerFactory.Chaos().V1alpha1().ChaosSchedules(),
+		chaosInformerFactory.Chaos().V1alpha1().ChaosExperiments(),
+	)
+
+	workflowController := controller.NewWorkflowController(
+		chaosClient,
+		chaosInformerFactory.Chaos().V1alpha1().ChaosWorkflows(),
+		chaosInformerFactory.Chaos().V1alpha1().ChaosExperiments(),
+	)
+
+	podExecutor := executor.NewRemoteExecutor(kubeClient, cfg)
+	injectors := injector.Injectors{
+		chaosv1alpha1.InjectorExec:      injector.NewExecInjector(podExecutor),
+		chaosv1alpha1.InjectorEphemeral: injector.NewEphemeralInjector(kubeClient, podExecutor, chaosToolsImage),
+	}
+	// The daemon injector needs the token the chaos daemons were deployed with and
+	// the CA that signed their certificates
+	if chaosDaemonTokenFile != "" {
+		token, err := os.ReadFile(chaosDaemonTokenFile)
+		if err != nil {
+			klog.Warningf("Daemon injector disabled, cannot read chaos daemon token: %s", err.Error())
+		} else if tlsConfig, err := daemonTLSConfig(chaosDaemonCAFile, chaosDaemonServer); err != nil {
+			klog.Warningf("Daemon injector disabled: %s", err.Error())
+		} else {
+			httpClient := &http.Client{
+				Timeout:   executor.DefaultTimeout,
+				Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			}
+			daemons := daemon.NewClient(httpClient, chaosDaemonPort, strings.TrimSpace(string(token)))
+			injectors[chaosv1alpha1.InjectorDaemon] = injector.NewDaemonInjector(kubeClient, daemons, chaosDaemonNamespace, chaosDaemonSelector)
+		}
+	}
+
 	controller := controller.NewController(
 		kubeClient,
 		chaosClient,
+		dynamicClient,
 		cfg,
+		injectors,
 		chaosInformerFactory.Chaos().V1alpha1().ChaosExperiments(),
 	)
 
@@ -58,7 +117,17 @@ func main() {
 	go kubeInformerFactory.Start(stopCh)
 	go chaosInformerFactory.Start(stopCh)
 
-	// Start the controller
+	// Start the controllers
+	go func() {
+		if err := scheduleController.Run(1, stopCh); err != nil {
+			klog.Fatalf("Error running schedule controller: %s", err.Error())
+		}
+	}()
+	go func() {
+		if err := workflowController.Run(1, stopCh); err != nil {
+			klog.Fatalf("Error running workflow controller: %s", err.Error())
+		}
+	}()
 	if err = controller.Run(2, stopCh); err != nil {
 		klog.Fatalf("Error running controller: %s", err.Error())
 	}
@@ -67,12 +136,40 @@ func main() {
 func init() {
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&chaosToolsImage, "chaos-tools-image", injector.DefaultToolsImage, "The image of the ephemeral containers that run the commands of experiments using the ephemeral injector.")
+	flag.StringVar(&chaosDaemonNamespace, "chaos-daemon-namespace", "chaos-engineering", "The namespace of the chaos daemon pods used by the daemon injector.")
+	flag.StringVar(&chaosDaemonSelector, "chaos-daemon-selector", injector.DefaultDaemonSelector, "The label selector of the chaos daemon pods used by the daemon injector.")
+	flag.IntVar(&chaosDaemonPort, "chaos-daemon-port", daemon.DefaultPort, "The port the chaos daemons listen on.")
+	flag.StringVar(&chaosDaemonTokenFile, "chaos-daemon-token-file", "", "Path to the token of the chaos daemons. The daemon injector is disabled without it.")
+	flag.StringVar(&chaosDaemonCAFile, "chaos-daemon-ca-file", "", "Path to the CA certificate that signed the certificates of the chaos daemons. The daemon injector is disabled without it.")
+	flag.StringVar(&chaosDaemonServer, "chaos-daemon-server-name", daemon.DefaultServerName, "The name the certificates of the chaos daemons are issued for.")
+}
+
+// daemonTLSConfig returns the TLS configuration that verifies the certificates of
+// chaos daemons against the CA in caFile
+func daemonTLSConfig(caFile, serverName string) (*tls.Config, error) {
+	if caFile == "" {
+		return nil, fmt.Errorf("no chaos daemon CA file is set")
+	}
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read chaos daemon CA: %v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("chaos daemon CA file %s holds no PEM certificate", caFile)
+	}
+	return &tls.Config{
+		RootCAs:    roots,
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}, nil
 }
 
 func setupSignalHandler() (stopCh <-chan struct{}) {
 	stop := make(chan struct{})
 	c := make(chan os.Signal, 2)
-	
+
 	// We'll accept graceful shutdowns when quit via SIGINT (Ctrl+C) or SIGTERM
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/webhook"
+	"k8s.io/klog/v2"
+)
+
+var (
+	port     int
+	certFile string
+	keyFile  string
+)
+
+func main() {
+	klog.InitFlags(nil)
+	flag.Parse()
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: webhook.NewServer(),
+	}
+
+	go func() {
+		klog.Infof("Starting webhook server on port %d", port)
+		if err := server.ListenAndServeTLS(certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.Fatalf("Error running webhook server: %s", err.Error())
+		}
+	}()
+
+	// Wait for a shutdown signal and let in-flight reviews finish
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		klog.Errorf("Error shutting down webhook server: %s", err.Error())
+	}
+}
+
+func init() {
+	flag.IntVar(&port, "port", 9443, "The port the webhook server listens on.")
+	flag.StringVar(&certFile, "tls-cert-file", "/etc/webhook/certs/tls.crt", "Path to the TLS certificate served by the webhook.")
+	flag.StringVar(&keyFile, "tls-private-key-file", "/etc/webhook/certs/tls.key", "Path to the TLS private key of the webhook.")
+}
@@ -22,7 +22,9 @@ const statusColors = {
   Pending: 'warning',
   Running: 'info',
   Completed: 'success',
-  Failed: 'error'
+  Failed: 'error',
+  Paused: 'default',
+  Aborted: 'error'
 };
 
 const Dashboard = () => {
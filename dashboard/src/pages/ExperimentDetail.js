@@ -11,16 +11,30 @@ import {
   Alert,
   Card,
   CardContent,
-  Divider
+  Divider,
+  Table,
+  TableBody,
+  TableCell,
+  TableContainer,
+  TableHead,
+  TableRow
 } from '@mui/material';
-import { ArrowBack as ArrowBackIcon, Delete as DeleteIcon } from '@mui/icons-material';
+import {
+  ArrowBack as ArrowBackIcon,
+  Delete as DeleteIcon,
+  Stop as StopIcon,
+  Pause as PauseIcon,
+  PlayArrow as PlayArrowIcon
+} from '@mui/icons-material';
 import api from '../services/api';
 
 const statusColors = {
   Pending: 'warning',
   Running: 'info',
   Completed: 'success',
-  Failed: 'error'
+  Failed: 'error',
+  Paused: 'default',
+  Aborted: 'error'
 };
 
 const ExperimentDetail = () => {
@@ -62,6 +76,32 @@ const ExperimentDetail = () => {
     }
   };
 
+  const handleAbort = async () => {
+    const reason = window.prompt(`Why are you aborting experiment ${name}?`);
+    if (reason === null) {
+      return;
+    }
+    try {
+      const updated = await api.abortExperiment(namespace, name, reason);
+      setExperiment(updated);
+    } catch (err) {
+      setError(`Failed to abort experiment ${name}. Please try again later.`);
+    }
+  };
+
+  const handlePauseResume = async () => {
+    try {
+      const updated = experiment.paused
+        ? await api.resumeExperiment(namespace, name)
+        : await api.pauseExperiment(namespace, name);
+      setExperiment(updated);
+    } catch (err) {
+      setError(`Failed to ${experiment.paused ? 'resume' : 'pause'} experiment ${name}. Please try again later.`);
+    }
+  };
+
+  const isActive = ['Pending', 'Running', 'Paused'].includes(experiment?.status || 'Pending');
+
   if (loading && !experiment) {
     return (
       <Box sx={{ display: 'flex', justifyContent: 'center', mt: 4 }}>
@@ -164,9 +204,65 @@ const ExperimentDetail = () => {
                   {experiment.message || 'No message'}
                 </Typography>
               </Grid>
+              {experiment.status === 'Aborted' && (
+                <Grid item xs={12}>
+                  <Typography variant="subtitle2" color="text.secondary">
+                    Aborted By
+                  </Typography>
+                  <Typography variant="body1">
+                    {experiment.abortedBy || 'Unknown'}{experiment.abortReason ? ` — ${experiment.abortReason}` : ''}
+                  </Typography>
+                </Grid>
+              )}
             </Grid>
           </Paper>
 
+          <Paper sx={{ p: 3, mb: 3 }}>
+            <Typography variant="h6" gutterBottom>
+              Targets
+            </Typography>
+            {experiment.targets && experiment.targets.length > 0 ? (
+              <TableContainer>
+                <Table size="small">
+                  <TableHead>
+                    <TableRow>
+                      <TableCell>Pod</TableCell>
+                      <TableCell>Containers</TableCell>
+                      <TableCell>Injected At</TableCell>
+                      <TableCell>Recovered At</TableCell>
+                      <TableCell>Error</TableCell>
+                    </TableRow>
+                  </TableHead>
+                  <TableBody>
+                    {experiment.targets.map((target) => (
+                      <TableRow key={target.uid || `${target.namespace}/${target.name}`}>
+                        <TableCell>{target.namespace}/{target.name}</TableCell>
+                        <TableCell>{target.containers?.length ? target.containers.join(', ') : 'default'}</TableCell>
+                        <TableCell>
+                          {target.injectedAt ? new Date(target.injectedAt).toLocaleString() : '-'}
+                        </TableCell>
+                        <TableCell>
+                          {target.recoveredAt ? new Date(target.recoveredAt).toLocaleString() : '-'}
+                        </TableCell>
+                        <TableCell>
+                          {target.error ? (
+                            <Typography variant="body2" color="error">
+                              {target.error}
+                            </Typography>
+                          ) : '-'}
+                        </TableCell>
+                      </TableRow>
+                    ))}
+                  </TableBody>
+                </Table>
+              </TableContainer>
+            ) : (
+              <Typography variant="body2" color="text.secondary">
+                No targets selected yet
+              </Typography>
+            )}
+          </Paper>
+
           <Paper sx={{ p: 3 }}>
             <Typography variant="h6" gutterBottom>
               Timeline
@@ -202,7 +298,7 @@ const ExperimentDetail = () => {
                 </Box>
               )}
 
-              {(experiment.status === 'Completed' || experiment.status === 'Failed') && (
+              {(experiment.status === 'Completed' || experiment.status === 'Failed' || experiment.status === 'Aborted') && (
                 <Box sx={{ position: 'relative' }}>
                   <Box sx={{ 
                     position: 'absolute', 
@@ -255,6 +351,29 @@ const ExperimentDetail = () => {
                 Actions
               </Typography>
               <Divider sx={{ mb: 2 }} />
+              {isActive && (
+                <>
+                  <Button
+                    variant="contained"
+                    color="error"
+                    startIcon={<StopIcon />}
+                    onClick={handleAbort}
+                    fullWidth
+                    sx={{ mb: 2 }}
+                  >
+                    Abort Experiment
+                  </Button>
+                  <Button
+                    variant="outlined"
+                    startIcon={experiment.paused ? <PlayArrowIcon /> : <PauseIcon />}
+                    onClick={handlePauseResume}
+                    fullWidth
+                    sx={{ mb: 2 }}
+                  >
+                    {experiment.paused ? 'Resume Experiment' : 'Pause Experiment'}
+                  </Button>
+                </>
+              )}
               <Button
                 variant="outlined"
                 color="error"
@@ -19,17 +19,40 @@ import api from '../services/api';
 const experimentTypes = [
   { value: 'pod-failure', label: 'Pod Failure', description: 'Kills a pod to test resilience to pod failures' },
   { value: 'network-latency', label: 'Network Latency', description: 'Adds latency to network traffic' },
+  { value: 'network-emulation', label: 'Network Emulation', description: 'Adds latency, loss, duplication, corruption, reordering or rate limits to network traffic' },
   { value: 'cpu-hog', label: 'CPU Hog', description: 'Consumes CPU resources' },
   { value: 'memory-hog', label: 'Memory Hog', description: 'Consumes memory resources' }
 ];
 
+// Parameters of a network emulation experiment, all optional but at least one impairment
+const networkEmulationParameters = [
+  { name: 'interface', label: 'Interface', helperText: 'Network interface to impair, defaults to eth0' },
+  { name: 'latency', label: 'Latency (e.g., 100ms)', helperText: 'Delay added to outgoing packets' },
+  { name: 'jitter', label: 'Jitter (e.g., 20ms)', helperText: 'Random variation of the latency' },
+  { name: 'loss', label: 'Loss (%)', helperText: 'Percentage of packets dropped' },
+  { name: 'duplicate', label: 'Duplicate (%)', helperText: 'Percentage of packets sent twice' },
+  { name: 'corrupt', label: 'Corrupt (%)', helperText: 'Percentage of packets with a flipped bit' },
+  { name: 'reorder', label: 'Reorder (%)', helperText: 'Percentage of packets sent ahead of the delayed ones, requires a latency' },
+  { name: 'rate', label: 'Rate (e.g., 1mbit)', helperText: 'Bandwidth limit' }
+];
+
 const targetKinds = [
   { value: 'Pod', label: 'Pod' },
   { value: 'Deployment', label: 'Deployment' },
   { value: 'StatefulSet', label: 'StatefulSet' },
+  { value: 'DaemonSet', label: 'DaemonSet' },
+  { value: 'ReplicaSet', label: 'ReplicaSet' },
   { value: 'Service', label: 'Service' }
 ];
 
+const modes = [
+  { value: 'all', label: 'All pods' },
+  { value: 'one', label: 'One random pod' },
+  { value: 'fixed', label: 'Fixed number of pods' },
+  { value: 'fixed-percent', label: 'Fixed percentage of pods' },
+  { value: 'random-max-percent', label: 'Random percentage of pods, up to' }
+];
+
 const durations = [
   { value: '30s', label: '30 seconds' },
   { value: '1m', label: '1 minute' },
@@ -48,6 +71,8 @@ const NewExperiment = () => {
     targetKind: 'Pod',
     experimentType: 'pod-failure',
     duration: '1m',
+    mode: 'all',
+    value: '',
     parameters: {}
   });
   const [loading, setLoading] = useState(false);
@@ -69,10 +94,9 @@ const NewExperiment = () => {
 
   const handleParameterChange = (e) => {
     const { name, value } = e.target;
-    setParameters({
-      ...parameters,
-      [name]: value
-    });
+    // Cleared parameters are left unset rather than sent empty
+    const { [name]: _, ...rest } = parameters;
+    setParameters(value === '' ? rest : { ...rest, [name]: value });
   };
 
   const handleSubmit = async (e) => {
@@ -111,6 +135,23 @@ const NewExperiment = () => {
             helperText="Amount of latency to add to network requests"
           />
         );
+      case 'network-emulation':
+        return (
+          <>
+            {networkEmulationParameters.map(({ name, label, helperText }) => (
+              <TextField
+                key={name}
+                fullWidth
+                label={label}
+                name={name}
+                value={parameters[name] || ''}
+                onChange={handleParameterChange}
+                margin="normal"
+                helperText={helperText}
+              />
+            ))}
+          </>
+        );
       case 'cpu-hog':
         return (
           <TextField
@@ -247,6 +288,37 @@ const NewExperiment = () => {
                 </Select>
               </FormControl>
             </Grid>
+            <Grid item xs={12} md={6}>
+              <FormControl fullWidth>
+                <InputLabel>Mode</InputLabel>
+                <Select
+                  name="mode"
+                  value={formData.mode}
+                  onChange={handleChange}
+                  label="Mode"
+                >
+                  {modes.map((mode) => (
+                    <MenuItem key={mode.value} value={mode.value}>
+                      {mode.label}
+                    </MenuItem>
+                  ))}
+                </Select>
+              </FormControl>
+            </Grid>
+            {['fixed', 'fixed-percent', 'random-max-percent'].includes(formData.mode) && (
+              <Grid item xs={12} md={6}>
+                <TextField
+                  fullWidth
+                  label={formData.mode === 'fixed' ? 'Number of Pods' : 'Percentage'}
+                  name="value"
+                  type="number"
+                  value={formData.value}
+                  onChange={handleChange}
+                  required
+                  helperText="How many of the target's pods to inject"
+                />
+              </Grid>
+            )}
             <Grid item xs={12}>
               <Typography variant="h6" gutterBottom>
                 Experiment Parameters
@@ -41,6 +41,36 @@ const api = {
       console.error(`Error deleting experiment ${namespace}/${name}:`, error);
       throw error;
     }
+  },
+
+  abortExperiment: async (namespace, name, reason) => {
+    try {
+      const response = await axios.post(`${API_URL}/experiments/${namespace}/${name}/abort`, { reason });
+      return response.data;
+    } catch (error) {
+      console.error(`Error aborting experiment ${namespace}/${name}:`, error);
+      throw error;
+    }
+  },
+
+  pauseExperiment: async (namespace, name) => {
+    try {
+      const response = await axios.post(`${API_URL}/experiments/${namespace}/${name}/pause`);
+      return response.data;
+    } catch (error) {
+      console.error(`Error pausing experiment ${namespace}/${name}:`, error);
+      throw error;
+    }
+  },
+
+  resumeExperiment: async (namespace, name) => {
+    try {
+      const response = await axios.post(`${API_URL}/experiments/${namespace}/${name}/resume`);
+      return response.data;
+    } catch (error) {
+      console.error(`Error resuming experiment ${namespace}/${name}:`, error);
+      throw error;
+    }
   }
 };
 
@@ -5,18 +5,56 @@ set -o nounset
 set -o pipefail
 
 SCRIPT_ROOT=$(dirname "${BASH_SOURCE[0]}")/..
-CODEGEN_PKG=${CODEGEN_PKG:-$(cd "${SCRIPT_ROOT}"; ls -d -1 ./vendor/k8s.io/code-generator 2>/dev/null || echo ../code-generator)}
-
-# Generate the code with:
-# --output-base    because this script should also be able to run inside the vendor dir of
-#                  k8s.io/kubernetes. The output-base is needed for the generators to output into the vendor dir
-#                  instead of the $GOPATH directly. For normal projects this can be dropped.
-bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy,client,informer,lister" \
-  github.com/chaos-engineering/controller/pkg/generated \
-  github.com/chaos-engineering/controller/pkg/chaos/apis \
-  chaos:v1alpha1 \
-  --output-base "$(dirname "${BASH_SOURCE[0]}")/../../../.." \
-  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate.go.txt
-
-# To use your own boilerplate text append:
-#   --go-header-file "${SCRIPT_ROOT}"/hack/custom-boilerplate.go.txt
+CODEGEN_PKG=${CODEGEN_PKG:-$(cd "${SCRIPT_ROOT}"; go mod download k8s.io/code-generator && go list -m -f '{{.Dir}}' k8s.io/code-generator)}
+
+# The generators write into ${OUTPUT_BASE}/github.com/chaos-engineering/controller,
+# so the repository has to be checked out at that path below it, as in a GOPATH.
+OUTPUT_BASE=${OUTPUT_BASE:-$(cd "${SCRIPT_ROOT}/../../.."; pwd)}
+BOILERPLATE="${SCRIPT_ROOT}/hack/boilerplate.go.txt"
+
+MODULE=github.com/chaos-engineering/controller
+OUTPUT_PKG="${MODULE}/pkg/generated"
+VERSIONS=(v1alpha1 v1alpha2)
+
+INPUT_DIRS=""
+for version in "${VERSIONS[@]}"; do
+  INPUT_DIRS+="${MODULE}/pkg/chaos/apis/chaos/${version},"
+done
+INPUT_DIRS="${INPUT_DIRS%,}"
+
+# Build the generators at the version pinned in go.mod
+GOBIN="$(mktemp -d)"
+trap 'rm -rf "${GOBIN}"' EXIT
+(cd "${CODEGEN_PKG}" && GOBIN="${GOBIN}" go install ./cmd/{deepcopy-gen,client-gen,lister-gen,informer-gen})
+
+echo "Generating deepcopy functions"
+"${GOBIN}/deepcopy-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  -O zz_generated.deepcopy \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
+
+echo "Generating clientset at ${OUTPUT_PKG}/clientset"
+"${GOBIN}/client-gen" \
+  --clientset-name versioned \
+  --input-base "" \
+  --input "${INPUT_DIRS}" \
+  --output-package "${OUTPUT_PKG}/clientset" \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
+
+echo "Generating listers at ${OUTPUT_PKG}/listers"
+"${GOBIN}/lister-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --output-package "${OUTPUT_PKG}/listers" \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
+
+echo "Generating informers at ${OUTPUT_PKG}/informers"
+"${GOBIN}/informer-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --versioned-clientset-package "${OUTPUT_PKG}/clientset/versioned" \
+  --listers-package "${OUTPUT_PKG}/listers" \
+  --output-package "${OUTPUT_PKG}/informers" \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
@@ -0,0 +1,37 @@
+// Package scheme holds a scheme with every version of the chaos.engineering API
+// group registered, along with the conversions between them.
+package scheme
+
+import (
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+var (
+	// Scheme knows every version of the chaos.engineering API group
+	Scheme = runtime.NewScheme()
+	// Codecs encodes and decodes the chaos.engineering API group
+	Codecs = serializer.NewCodecFactory(Scheme)
+)
+
+func init() {
+	metav1.AddToGroupVersion(Scheme, schema.GroupVersion{Version: "v1"})
+	utilruntime.Must(AddToScheme(Scheme))
+}
+
+// AddToScheme adds every version of the chaos.engineering API group to the given
+// scheme, preferring the storage version v1alpha1
+func AddToScheme(scheme *runtime.Scheme) error {
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := v1alpha2.AddToScheme(scheme); err != nil {
+		return err
+	}
+	return scheme.SetVersionPriority(v1alpha1.SchemeGroupVersion, v1alpha2.SchemeGroupVersion)
+}
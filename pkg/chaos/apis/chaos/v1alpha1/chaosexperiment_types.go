@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // Phase constants for experiment status
@@ -10,6 +11,84 @@ const (
 	PhaseRunning   = "Running"
 	PhaseCompleted = "Completed"
 	PhaseFailed    = "Failed"
+	PhasePaused    = "Paused"
+	PhaseAborted   = "Aborted"
+)
+
+// Injectors that run the commands of exec based experiments
+const (
+	// InjectorExec runs the commands in the target containers
+	InjectorExec = "exec"
+	// InjectorEphemeral runs the commands in an ephemeral container that
+	// shares the namespaces of the target container
+	InjectorEphemeral = "ephemeral"
+	// InjectorDaemon runs the commands through the chaos daemon on the node of
+	// the target pod, in the namespaces of the target container
+	InjectorDaemon = "daemon"
+)
+
+const (
+	// ExperimentFinalizer blocks deletion of an experiment until its targets are recovered
+	ExperimentFinalizer = "chaos.engineering/rollback"
+	// AnnotationForceDelete removes the finalizer even when targets failed to recover
+	AnnotationForceDelete = "chaos.engineering/force-delete"
+	// AnnotationAbort set to "true" stops a pending, running or paused experiment for good
+	AnnotationAbort = "chaos.engineering/abort"
+	// AnnotationAbortReason records why an experiment was aborted
+	AnnotationAbortReason = "chaos.engineering/abort-reason"
+	// AnnotationAbortedBy records who aborted an experiment
+	AnnotationAbortedBy = "chaos.engineering/aborted-by"
+)
+
+// Condition types of an experiment
+const (
+	// ConditionValidated reports whether the experiment spec is valid
+	ConditionValidated = "Validated"
+	// ConditionSelected reports whether the target resolved to pods
+	ConditionSelected = "Selected"
+	// ConditionInjected reports whether the experiment is injected into every target
+	ConditionInjected = "Injected"
+	// ConditionRecovered reports whether every target has been recovered
+	ConditionRecovered = "Recovered"
+	// ConditionAborted reports whether the experiment was aborted before completing
+	ConditionAborted = "Aborted"
+	// ConditionSteadyState reports whether the steady state probes have held so far
+	ConditionSteadyState = "SteadyState"
+)
+
+// Condition reasons of an experiment
+const (
+	ReasonValid             = "Valid"
+	ReasonInvalidSpec       = "InvalidSpec"
+	ReasonPodsSelected      = "PodsSelected"
+	ReasonSelectionFailed   = "SelectionFailed"
+	ReasonInjected          = "Injected"
+	ReasonInjectionFailed   = "InjectionFailed"
+	ReasonNotInjected       = "NotInjected"
+	ReasonRecovered         = "Recovered"
+	ReasonRecoveryFailed    = "RecoveryFailed"
+	ReasonNotRecovered      = "NotRecovered"
+	ReasonNotAborted        = "NotAborted"
+	ReasonDeleted           = "Deleted"
+	ReasonAbortRequested    = "AbortRequested"
+	ReasonPaused            = "Paused"
+	ReasonSteadyStateMet    = "SteadyStateMet"
+	ReasonSteadyStateNotMet = "SteadyStateNotMet"
+	ReasonProbeFailed       = "ProbeFailed"
+)
+
+// Mode constants select how many of the target pods an experiment is injected into
+const (
+	// ModeOne injects a single random pod
+	ModeOne = "one"
+	// ModeFixed injects Value random pods
+	ModeFixed = "fixed"
+	// ModeFixedPercent injects Value percent of the pods
+	ModeFixedPercent = "fixed-percent"
+	// ModeRandomMaxPercent injects a random percentage of the pods, up to Value percent
+	ModeRandomMaxPercent = "random-max-percent"
+	// ModeAll injects every pod
+	ModeAll = "all"
 )
 
 // +genclient
@@ -34,6 +113,25 @@ type ChaosExperimentSpec struct {
 	Duration string `json:"duration"`
 	// Parameters are the parameters for the experiment
 	Parameters map[string]string `json:"parameters,omitempty"`
+	// Mode selects how many of the target pods are injected. Defaults to all.
+	Mode string `json:"mode,omitempty"`
+	// Value is the pod count or percentage used by the fixed, fixed-percent
+	// and random-max-percent modes
+	Value string `json:"value,omitempty"`
+	// Seed makes the random pod selection reproducible. When unset a seed is
+	// generated and recorded in the status.
+	Seed *int64 `json:"seed,omitempty"`
+	// Paused recovers the targets of a running experiment until it is unset
+	// again. Time spent paused does not count towards the duration.
+	Paused bool `json:"paused,omitempty"`
+	// SteadyState is checked before the targets are injected, while they are
+	// injected and after they are recovered
+	SteadyState *SteadyState `json:"steadyState,omitempty"`
+	// Injector selects how exec based experiments run their commands: exec
+	// (default) runs them in the target containers, ephemeral in an ephemeral
+	// container with its own tools that shares the namespaces of the target and
+	// daemon through the chaos daemon on the node of the target
+	Injector string `json:"injector,omitempty"`
 }
 
 // TargetResource defines the target resource for the chaos experiment
@@ -43,9 +141,17 @@ type TargetResource struct {
 	// Kind of the target resource
 	Kind string `json:"kind"`
 	// Name of the target resource
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 	// Namespace of the target resource
-	Namespace string `json:"namespace"`
+	Namespace string `json:"namespace,omitempty"`
+	// Selector selects the target pods by label instead of by name
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// NamespaceSelector selects the namespaces to search for target pods.
+	// When unset, only Namespace is searched.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ContainerNames are the containers of the target pods that exec based
+	// experiments are injected into. Defaults to the default container.
+	ContainerNames []string `json:"containerNames,omitempty"`
 }
 
 // ChaosExperimentStatus defines the observed state of ChaosExperiment
@@ -58,6 +164,57 @@ type ChaosExperimentStatus struct {
 	EndTime *metav1.Time `json:"endTime,omitempty"`
 	// Message provides more details about the current phase
 	Message string `json:"message,omitempty"`
+	// SelectedPods are the pods selected for injection when the experiment started
+	SelectedPods []PodReference `json:"selectedPods,omitempty"`
+	// Seed is the seed the pod selection was made with
+	Seed int64 `json:"seed,omitempty"`
+	// Targets records the injection state of every selected pod
+	Targets []TargetStatus `json:"targets,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// PausedAt is when the experiment was last paused
+	PausedAt *metav1.Time `json:"pausedAt,omitempty"`
+	// PausedDuration is the total time the experiment has spent paused
+	PausedDuration metav1.Duration `json:"pausedDuration,omitempty"`
+	// AbortedBy is who aborted the experiment
+	AbortedBy string `json:"abortedBy,omitempty"`
+	// AbortReason is why the experiment was aborted
+	AbortReason string `json:"abortReason,omitempty"`
+	// Result is whether the steady state hypothesis held, once it has been
+	// checked after the experiment or has failed
+	Result string `json:"result,omitempty"`
+	// Probes records the last evaluation of every steady state probe
+	// +listType=map
+	// +listMapKey=name
+	Probes []ProbeStatus `json:"probes,omitempty"`
+	// Conditions are the latest observations of the experiment's state
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PodReference identifies a pod selected by an experiment
+type PodReference struct {
+	// Namespace of the pod
+	Namespace string `json:"namespace"`
+	// Name of the pod
+	Name string `json:"name"`
+	// UID of the pod
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// TargetStatus records the injection state of a single target
+type TargetStatus struct {
+	PodReference `json:",inline"`
+	// Containers the experiment was injected into. Empty when the experiment
+	// acts on the whole pod or on its default container.
+	Containers []string `json:"containers,omitempty"`
+	// InjectedAt is when the experiment was injected into the target
+	InjectedAt *metav1.Time `json:"injectedAt,omitempty"`
+	// RecoveredAt is when the experiment was rolled back on the target
+	RecoveredAt *metav1.Time `json:"recoveredAt,omitempty"`
+	// Error is the last injection or recovery error for the target
+	Error string `json:"error,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
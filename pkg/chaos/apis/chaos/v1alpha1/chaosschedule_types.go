@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Concurrency policies of a schedule
+const (
+	// ConcurrencyAllow starts experiments even when earlier ones are still running
+	ConcurrencyAllow = "Allow"
+	// ConcurrencyForbid holds back a run while an earlier experiment is still running,
+	// skipping it once its starting deadline has passed
+	ConcurrencyForbid = "Forbid"
+	// ConcurrencyReplace deletes running experiments and starts a new one once they are gone
+	ConcurrencyReplace = "Replace"
+)
+
+// Condition type and reasons of a schedule
+const (
+	// ConditionScheduled reports whether the schedule can work out its next run
+	ConditionScheduled      = "Scheduled"
+	ReasonOnSchedule        = "OnSchedule"
+	ReasonTooManyMissedRuns = "TooManyMissedRuns"
+)
+
+// LabelSchedule is set on experiments created by a schedule to the schedule's name
+const LabelSchedule = "chaos.engineering/schedule"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChaosSchedule runs a chaos experiment on a cron schedule
+type ChaosSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChaosScheduleSpec   `json:"spec,omitempty"`
+	Status ChaosScheduleStatus `json:"status,omitempty"`
+}
+
+// ChaosScheduleSpec defines the desired state of ChaosSchedule
+type ChaosScheduleSpec struct {
+	// Schedule is the cron expression the experiments are started on, e.g. "0 10 * * 1-5"
+	Schedule string `json:"schedule"`
+	// ExperimentTemplate is the spec of the experiments that are created
+	ExperimentTemplate ChaosExperimentSpec `json:"experimentTemplate"`
+	// ConcurrencyPolicy is one of Allow, Forbid or Replace. Defaults to Forbid.
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+	// StartingDeadlineSeconds is how late a run may start before it is skipped.
+	// When unset, a missed run is started however late it is, unless more than 100
+	// runs were missed, in which case the schedule stops until a deadline is set.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// Suspend stops new experiments from being started. Running ones are not affected.
+	Suspend bool `json:"suspend,omitempty"`
+	// SuccessfulExperimentsHistoryLimit is how many completed experiments to keep. Defaults to 3.
+	SuccessfulExperimentsHistoryLimit *int32 `json:"successfulExperimentsHistoryLimit,omitempty"`
+	// FailedExperimentsHistoryLimit is how many failed or aborted experiments to keep. Defaults to 1.
+	FailedExperimentsHistoryLimit *int32 `json:"failedExperimentsHistoryLimit,omitempty"`
+}
+
+// ChaosScheduleStatus defines the observed state of ChaosSchedule
+type ChaosScheduleStatus struct {
+	// Active are the experiments of the schedule that have not finished yet
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+	// LastScheduleTime is the scheduled time of the last run that was started or skipped
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the latest observations of the schedule's state
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChaosScheduleList contains a list of ChaosSchedule
+type ChaosScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChaosSchedule `json:"items"`
+}
@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Step types of a workflow
+const (
+	// StepTypeExperiment runs a chaos experiment until it finishes
+	StepTypeExperiment = "Experiment"
+	// StepTypeSerial runs its children one after another
+	StepTypeSerial = "Serial"
+	// StepTypeParallel runs its children at the same time
+	StepTypeParallel = "Parallel"
+	// StepTypeSuspend waits for its duration
+	StepTypeSuspend = "Suspend"
+)
+
+const (
+	// LabelWorkflow is set on experiments created by a workflow to the workflow's name
+	LabelWorkflow = "chaos.engineering/workflow"
+	// LabelWorkflowStep is set on experiments created by a workflow to the name of their step
+	LabelWorkflowStep = "chaos.engineering/workflow-step"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChaosWorkflow runs chaos experiments as a graph of serial, parallel and suspend steps.
+// Annotating it with chaos.engineering/abort=true aborts every active step.
+type ChaosWorkflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChaosWorkflowSpec   `json:"spec,omitempty"`
+	Status ChaosWorkflowStatus `json:"status,omitempty"`
+}
+
+// ChaosWorkflowSpec defines the desired state of ChaosWorkflow
+type ChaosWorkflowSpec struct {
+	// Entry is the name of the step the workflow runs
+	Entry string `json:"entry"`
+	// Steps are the steps of the workflow. Serial and Parallel steps refer to
+	// their children by name, and a step referred to more than once runs once.
+	Steps []WorkflowStep `json:"steps"`
+}
+
+// WorkflowStep is a single step of a workflow
+type WorkflowStep struct {
+	// Name identifies the step within the workflow
+	Name string `json:"name"`
+	// Type is one of Experiment, Serial, Parallel or Suspend
+	Type string `json:"type"`
+	// Children are the names of the steps run by a Serial or Parallel step
+	Children []string `json:"children,omitempty"`
+	// Duration is how long a Suspend step waits
+	Duration string `json:"duration,omitempty"`
+	// ExperimentRef names a ChaosExperiment in the workflow's namespace whose
+	// spec an Experiment step runs, unpaused. The referenced experiment runs on
+	// its own as well unless it is created paused. Exclusive with Experiment.
+	ExperimentRef *corev1.LocalObjectReference `json:"experimentRef,omitempty"`
+	// Experiment is the spec an Experiment step runs. Exclusive with ExperimentRef.
+	Experiment *ChaosExperimentSpec `json:"experiment,omitempty"`
+}
+
+// ChaosWorkflowStatus defines the observed state of ChaosWorkflow
+type ChaosWorkflowStatus struct {
+	// Phase represents the current phase of the workflow
+	Phase string `json:"phase,omitempty"`
+	// StartTime is when the workflow started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is when the workflow ended
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Message provides more details about the current phase
+	Message string `json:"message,omitempty"`
+	// Steps records the state of every step
+	// +listType=map
+	// +listMapKey=name
+	Steps []WorkflowStepStatus `json:"steps,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the latest observations of the workflow's state
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// WorkflowStepStatus records the state of a single step
+type WorkflowStepStatus struct {
+	// Name of the step
+	Name string `json:"name"`
+	// Phase of the step, using the experiment phases
+	Phase string `json:"phase,omitempty"`
+	// StartTime is when the step started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is when the step ended
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Experiment is the name of the experiment created for an Experiment step
+	Experiment string `json:"experiment,omitempty"`
+	// Message provides more details about the step
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChaosWorkflowList contains a list of ChaosWorkflow
+type ChaosWorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChaosWorkflow `json:"items"`
+}
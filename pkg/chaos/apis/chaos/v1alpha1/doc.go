@@ -0,0 +1,4 @@
+// Package v1alpha1 contains the v1alpha1 version of the chaos.engineering API group.
+// +k8s:deepcopy-gen=package
+// +groupName=chaos.engineering
+package v1alpha1
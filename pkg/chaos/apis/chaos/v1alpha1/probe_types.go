@@ -0,0 +1,133 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Stages of an experiment at which the steady state probes are evaluated
+const (
+	// ProbeStageBefore is before the targets are injected
+	ProbeStageBefore = "Before"
+	// ProbeStageDuring is while the targets are injected
+	ProbeStageDuring = "During"
+	// ProbeStageAfter is after the targets are recovered
+	ProbeStageAfter = "After"
+)
+
+// Results of the steady state hypothesis of an experiment
+const (
+	// ResultPassed means every probe passed at every stage
+	ResultPassed = "Passed"
+	// ResultFailed means a probe failed at some stage
+	ResultFailed = "Failed"
+)
+
+// SteadyState is the hypothesis that the system is healthy before, during and
+// after the experiment
+type SteadyState struct {
+	// Probes are the checks that must all pass for the hypothesis to hold
+	Probes []Probe `json:"probes"`
+	// Interval is how often the probes are evaluated while the experiment
+	// runs. Defaults to 10s.
+	Interval string `json:"interval,omitempty"`
+}
+
+// Probe is a single check of the steady state. Exactly one of HTTP,
+// ResourceCondition, PodReadiness, Exec and Prometheus must be set.
+type Probe struct {
+	// Name identifies the probe within the experiment
+	Name string `json:"name"`
+	// Timeout bounds a single evaluation of the probe. Defaults to 5s.
+	Timeout string `json:"timeout,omitempty"`
+	// AbortThreshold aborts the experiment once the probe fails this many
+	// times in a row while the experiment runs. When unset, failures only
+	// fail the result of the experiment.
+	AbortThreshold int32 `json:"abortThreshold,omitempty"`
+	// HTTP passes when a GET request returns the expected response
+	HTTP *HTTPProbe `json:"http,omitempty"`
+	// ResourceCondition passes when a resource has a status condition
+	ResourceCondition *ResourceConditionProbe `json:"resourceCondition,omitempty"`
+	// PodReadiness passes when enough of the selected pods are ready
+	PodReadiness *PodReadinessProbe `json:"podReadiness,omitempty"`
+	// Exec passes when a command run in a pod exits with status 0
+	Exec *ExecProbe `json:"exec,omitempty"`
+	// Prometheus passes when the result of a PromQL query meets a threshold
+	Prometheus *PrometheusProbe `json:"prometheus,omitempty"`
+}
+
+// HTTPProbe requests a URL with GET
+type HTTPProbe struct {
+	// URL to request
+	URL string `json:"url"`
+	// ExpectedStatus is the status code the response must have. Defaults to 200.
+	ExpectedStatus int32 `json:"expectedStatus,omitempty"`
+	// ExpectedBody is text the response body must contain
+	ExpectedBody string `json:"expectedBody,omitempty"`
+}
+
+// ResourceConditionProbe checks a status condition of a Kubernetes resource
+type ResourceConditionProbe struct {
+	// API version of the resource
+	APIVersion string `json:"apiVersion"`
+	// Kind of the resource
+	Kind string `json:"kind"`
+	// Name of the resource
+	Name string `json:"name"`
+	// Namespace of the resource. Defaults to the namespace of the experiment.
+	Namespace string `json:"namespace,omitempty"`
+	// ConditionType is the type of the condition to check, e.g. Available
+	ConditionType string `json:"conditionType"`
+	// ConditionStatus is the status the condition must have. Defaults to True.
+	ConditionStatus string `json:"conditionStatus,omitempty"`
+}
+
+// PodReadinessProbe counts the ready pods matching a selector
+type PodReadinessProbe struct {
+	// Namespace of the pods. Defaults to the namespace of the experiment.
+	Namespace string `json:"namespace,omitempty"`
+	// Selector selects the pods to count
+	Selector metav1.LabelSelector `json:"selector"`
+	// MinReady is how many of the selected pods must be ready. Defaults to 1.
+	MinReady int32 `json:"minReady,omitempty"`
+}
+
+// ExecProbe runs a command in a pod
+type ExecProbe struct {
+	// Namespace of the pod. Defaults to the namespace of the experiment.
+	Namespace string `json:"namespace,omitempty"`
+	// Pod is the name of the pod to run the command in
+	Pod string `json:"pod"`
+	// Container to run the command in. Defaults to the pod's default container.
+	Container string `json:"container,omitempty"`
+	// Command to run
+	Command []string `json:"command"`
+}
+
+// PrometheusProbe evaluates an instant PromQL query against a Prometheus
+// compatible HTTP API and compares every sample of the result to a threshold
+type PrometheusProbe struct {
+	// Address is the base URL of the API, e.g. http://prometheus.monitoring:9090
+	Address string `json:"address"`
+	// Query is the PromQL expression to evaluate
+	Query string `json:"query"`
+	// Comparator is how the samples compare to the threshold: <, <=, >, >=, == or !=
+	Comparator string `json:"comparator"`
+	// Threshold is the number the samples are compared to
+	Threshold string `json:"threshold"`
+}
+
+// ProbeStatus records the last evaluation of a probe
+type ProbeStatus struct {
+	// Name of the probe
+	Name string `json:"name"`
+	// Stage is the stage of the experiment the probe was last evaluated at
+	Stage string `json:"stage,omitempty"`
+	// Passed is whether the last evaluation passed
+	Passed bool `json:"passed"`
+	// ConsecutiveFailures is how many evaluations in a row have failed
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// Message is the evidence of the last evaluation
+	Message string `json:"message,omitempty"`
+	// LastProbeTime is when the probe was last evaluated
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
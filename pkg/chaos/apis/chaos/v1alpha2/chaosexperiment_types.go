@@ -0,0 +1,218 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Experiment types, each selecting the parameter field of the spec that applies
+const (
+	ExperimentTypePodFailure       = "pod-failure"
+	ExperimentTypeNetworkLatency   = "network-latency"
+	ExperimentTypeNetworkEmulation = "network-emulation"
+	ExperimentTypeCPUHog           = "cpu-hog"
+	ExperimentTypeMemoryHog        = "memory-hog"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChaosExperiment is the Schema for the chaosexperiments API
+type ChaosExperiment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChaosExperimentSpec   `json:"spec,omitempty"`
+	Status ChaosExperimentStatus `json:"status,omitempty"`
+}
+
+// ChaosExperimentSpec defines the desired state of ChaosExperiment
+// +union
+type ChaosExperimentSpec struct {
+	// Target defines the target resource for the chaos experiment
+	Target TargetResource `json:"target"`
+	// ExperimentType is the type of chaos experiment to run. Only the
+	// parameters field matching the type may be set.
+	// +unionDiscriminator
+	ExperimentType string `json:"experimentType"`
+	// Duration is how long the experiment should run
+	Duration string `json:"duration"`
+	// PodFailure holds the parameters of a pod-failure experiment
+	// +optional
+	PodFailure *PodFailureParameters `json:"podFailure,omitempty"`
+	// NetworkLatency holds the parameters of a network-latency experiment
+	// +optional
+	NetworkLatency *NetworkLatencyParameters `json:"networkLatency,omitempty"`
+	// NetworkEmulation holds the parameters of a network-emulation experiment
+	// +optional
+	NetworkEmulation *NetworkEmulationParameters `json:"networkEmulation,omitempty"`
+	// CPUHog holds the parameters of a cpu-hog experiment
+	// +optional
+	CPUHog *CPUHogParameters `json:"cpuHog,omitempty"`
+	// MemoryHog holds the parameters of a memory-hog experiment
+	// +optional
+	MemoryHog *MemoryHogParameters `json:"memoryHog,omitempty"`
+	// Mode selects how many of the target pods are injected. Defaults to all.
+	Mode string `json:"mode,omitempty"`
+	// Value is the pod count or percentage used by the fixed, fixed-percent
+	// and random-max-percent modes
+	Value string `json:"value,omitempty"`
+	// Seed makes the random pod selection reproducible. When unset a seed is
+	// generated and recorded in the status.
+	Seed *int64 `json:"seed,omitempty"`
+	// Paused recovers the targets of a running experiment until it is unset
+	// again. Time spent paused does not count towards the duration.
+	Paused bool `json:"paused,omitempty"`
+	// SteadyState is checked before the targets are injected, while they are
+	// injected and after they are recovered
+	SteadyState *SteadyState `json:"steadyState,omitempty"`
+	// Injector selects how exec based experiments run their commands: exec
+	// (default) runs them in the target containers, ephemeral in an ephemeral
+	// container with its own tools that shares the namespaces of the target and
+	// daemon through the chaos daemon on the node of the target
+	Injector string `json:"injector,omitempty"`
+}
+
+// PodFailureParameters are the parameters of a pod-failure experiment, which takes none
+type PodFailureParameters struct {
+}
+
+// NetworkLatencyParameters are the parameters of a network-latency experiment
+type NetworkLatencyParameters struct {
+	// Latency is the delay added to outgoing packets. Defaults to 100ms.
+	Latency *metav1.Duration `json:"latency,omitempty"`
+	// Jitter is the random variation added to the latency
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+}
+
+// NetworkEmulationParameters are the parameters of a network-emulation experiment,
+// which must set at least one of latency, loss, duplicate, corrupt, reorder and
+// rate. Percentages are between 0 and 100, e.g. "10" or "0.5%".
+type NetworkEmulationParameters struct {
+	// Interface is the network interface of the target pods. Defaults to eth0.
+	Interface string `json:"interface,omitempty"`
+	// Latency is the delay added to outgoing packets
+	Latency *metav1.Duration `json:"latency,omitempty"`
+	// Jitter is the random variation added to the latency
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+	// LatencyCorrelation is how much each jitter depends on the previous one
+	LatencyCorrelation string `json:"latencyCorrelation,omitempty"`
+	// Loss is the percentage of packets dropped
+	Loss            string `json:"loss,omitempty"`
+	LossCorrelation string `json:"lossCorrelation,omitempty"`
+	// Duplicate is the percentage of packets sent twice
+	Duplicate            string `json:"duplicate,omitempty"`
+	DuplicateCorrelation string `json:"duplicateCorrelation,omitempty"`
+	// Corrupt is the percentage of packets with a flipped bit
+	Corrupt            string `json:"corrupt,omitempty"`
+	CorruptCorrelation string `json:"corruptCorrelation,omitempty"`
+	// Reorder is the percentage of packets sent ahead of the delayed ones. It requires a latency.
+	Reorder            string `json:"reorder,omitempty"`
+	ReorderCorrelation string `json:"reorderCorrelation,omitempty"`
+	// Rate limits the bandwidth, e.g. 1mbit
+	Rate string `json:"rate,omitempty"`
+}
+
+// CPUHogParameters are the parameters of a cpu-hog experiment
+type CPUHogParameters struct {
+	// CPUCores is the number of cores to keep busy. Defaults to 1.
+	CPUCores *int32 `json:"cpuCores,omitempty"`
+}
+
+// MemoryHogParameters are the parameters of a memory-hog experiment
+type MemoryHogParameters struct {
+	// MemoryMB is the amount of memory to allocate in megabytes. Defaults to 256.
+	MemoryMB *int32 `json:"memoryMB,omitempty"`
+}
+
+// TargetResource defines the target resource for the chaos experiment
+type TargetResource struct {
+	// API version of the target resource
+	APIVersion string `json:"apiVersion"`
+	// Kind of the target resource
+	Kind string `json:"kind"`
+	// Name of the target resource
+	Name string `json:"name,omitempty"`
+	// Namespace of the target resource
+	Namespace string `json:"namespace,omitempty"`
+	// Selector selects the target pods by label instead of by name
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// NamespaceSelector selects the namespaces to search for target pods.
+	// When unset, only Namespace is searched.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ContainerNames are the containers of the target pods that exec based
+	// experiments are injected into. Defaults to the default container.
+	ContainerNames []string `json:"containerNames,omitempty"`
+}
+
+// ChaosExperimentStatus defines the observed state of ChaosExperiment
+type ChaosExperimentStatus struct {
+	// Phase represents the current phase of the experiment
+	Phase string `json:"phase,omitempty"`
+	// StartTime is when the experiment started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is when the experiment ended
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Message provides more details about the current phase
+	Message string `json:"message,omitempty"`
+	// SelectedPods are the pods selected for injection when the experiment started
+	SelectedPods []PodReference `json:"selectedPods,omitempty"`
+	// Seed is the seed the pod selection was made with
+	Seed int64 `json:"seed,omitempty"`
+	// Targets records the injection state of every selected pod
+	Targets []TargetStatus `json:"targets,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// PausedAt is when the experiment was last paused
+	PausedAt *metav1.Time `json:"pausedAt,omitempty"`
+	// PausedDuration is the total time the experiment has spent paused
+	PausedDuration metav1.Duration `json:"pausedDuration,omitempty"`
+	// AbortedBy is who aborted the experiment
+	AbortedBy string `json:"abortedBy,omitempty"`
+	// AbortReason is why the experiment was aborted
+	AbortReason string `json:"abortReason,omitempty"`
+	// Result is whether the steady state hypothesis held, once it has been
+	// checked after the experiment or has failed
+	Result string `json:"result,omitempty"`
+	// Probes records the last evaluation of every steady state probe
+	// +listType=map
+	// +listMapKey=name
+	Probes []ProbeStatus `json:"probes,omitempty"`
+	// Conditions are the latest observations of the experiment's state
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PodReference identifies a pod selected by an experiment
+type PodReference struct {
+	// Namespace of the pod
+	Namespace string `json:"namespace"`
+	// Name of the pod
+	Name string `json:"name"`
+	// UID of the pod
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// TargetStatus records the injection state of a single target
+type TargetStatus struct {
+	PodReference `json:",inline"`
+	// Containers the experiment was injected into. Empty when the experiment
+	// acts on the whole pod or on its default container.
+	Containers []string `json:"containers,omitempty"`
+	// InjectedAt is when the experiment was injected into the target
+	InjectedAt *metav1.Time `json:"injectedAt,omitempty"`
+	// RecoveredAt is when the experiment was rolled back on the target
+	RecoveredAt *metav1.Time `json:"recoveredAt,omitempty"`
+	// Error is the last injection or recovery error for the target
+	Error string `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChaosExperimentList contains a list of ChaosExperiment
+type ChaosExperimentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChaosExperiment `json:"items"`
+}
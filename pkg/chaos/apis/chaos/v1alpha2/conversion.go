@@ -0,0 +1,453 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AnnotationV1alpha1Parameters keeps the v1alpha1 parameters that have no typed
+// v1alpha2 field, such as unknown keys or unparseable values, and the original
+// strings of typed parameters written differently than v1alpha2 formats them,
+// such as 1000ms for 1s, so that converting back to v1alpha1 restores them
+const AnnotationV1alpha1Parameters = "chaos.engineering/v1alpha1-parameters"
+
+func init() {
+	localSchemeBuilder.Register(addConversionFuncs)
+}
+
+// addConversionFuncs registers the conversions between v1alpha1 and v1alpha2 with the scheme
+func addConversionFuncs(scheme *runtime.Scheme) error {
+	if err := scheme.AddConversionFunc((*v1alpha1.ChaosExperiment)(nil), (*ChaosExperiment)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return ConvertFromV1alpha1(a.(*v1alpha1.ChaosExperiment), b.(*ChaosExperiment))
+	}); err != nil {
+		return err
+	}
+	return scheme.AddConversionFunc((*ChaosExperiment)(nil), (*v1alpha1.ChaosExperiment)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return ConvertToV1alpha1(a.(*ChaosExperiment), b.(*v1alpha1.ChaosExperiment))
+	})
+}
+
+// ConvertFromV1alpha1 converts a v1alpha1 experiment into a v1alpha2 experiment
+func ConvertFromV1alpha1(in *v1alpha1.ChaosExperiment, out *ChaosExperiment) error {
+	out.TypeMeta = metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "ChaosExperiment"}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	out.Spec = ChaosExperimentSpec{
+		Target:         convertTargetFromV1alpha1(in.Spec.Target),
+		ExperimentType: in.Spec.ExperimentType,
+		Duration:       in.Spec.Duration,
+		Mode:           in.Spec.Mode,
+		Value:          in.Spec.Value,
+		Seed:           copyInt64(in.Spec.Seed),
+		Paused:         in.Spec.Paused,
+		SteadyState:    convertSteadyStateFromV1alpha1(in.Spec.SteadyState),
+		Injector:       in.Spec.Injector,
+	}
+
+	preserved := setParameters(&out.Spec, in.Spec.Parameters)
+	if len(preserved) > 0 {
+		data, err := json.Marshal(preserved)
+		if err != nil {
+			return fmt.Errorf("failed to encode preserved parameters: %v", err)
+		}
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[AnnotationV1alpha1Parameters] = string(data)
+	} else {
+		delete(out.Annotations, AnnotationV1alpha1Parameters)
+	}
+
+	out.Status = convertStatusFromV1alpha1(in.Status)
+	return nil
+}
+
+// ConvertToV1alpha1 converts a v1alpha2 experiment into a v1alpha1 experiment
+func ConvertToV1alpha1(in *ChaosExperiment, out *v1alpha1.ChaosExperiment) error {
+	out.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ChaosExperiment"}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	preserved := map[string]string{}
+	if data, ok := out.Annotations[AnnotationV1alpha1Parameters]; ok {
+		if err := json.Unmarshal([]byte(data), &preserved); err != nil {
+			return fmt.Errorf("failed to decode %s annotation: %v", AnnotationV1alpha1Parameters, err)
+		}
+		delete(out.Annotations, AnnotationV1alpha1Parameters)
+		if len(out.Annotations) == 0 {
+			out.Annotations = nil
+		}
+	}
+	parameters := getParameters(&in.Spec)
+	for name, value := range preserved {
+		if restoreParameter(in.Spec.ExperimentType, name, value, parameters) {
+			parameters[name] = value
+		}
+	}
+	if len(parameters) == 0 {
+		parameters = nil
+	}
+
+	out.Spec = v1alpha1.ChaosExperimentSpec{
+		Target:         convertTargetToV1alpha1(in.Spec.Target),
+		ExperimentType: in.Spec.ExperimentType,
+		Duration:       in.Spec.Duration,
+		Parameters:     parameters,
+		Mode:           in.Spec.Mode,
+		Value:          in.Spec.Value,
+		Seed:           copyInt64(in.Spec.Seed),
+		Paused:         in.Spec.Paused,
+		SteadyState:    convertSteadyStateToV1alpha1(in.Spec.SteadyState),
+		Injector:       in.Spec.Injector,
+	}
+
+	out.Status = convertStatusToV1alpha1(in.Status)
+	return nil
+}
+
+// setParameters sets the typed parameters of the spec from v1alpha1 parameters,
+// returning the ones that have no typed field or that the typed field formats
+// differently
+func setParameters(spec *ChaosExperimentSpec, parameters map[string]string) map[string]string {
+	preserved := map[string]string{}
+	for name, value := range parameters {
+		if !setParameter(spec, name, value) {
+			preserved[name] = value
+		}
+	}
+	formatted := getParameters(spec)
+	for name, value := range parameters {
+		if _, ok := preserved[name]; ok {
+			continue
+		}
+		if f, ok := formatted[name]; !ok || f != value {
+			preserved[name] = value
+		}
+	}
+	return preserved
+}
+
+// restoreParameter reports whether a preserved v1alpha1 parameter belongs in the
+// converted parameters. Typed fields set in v1alpha2 win, so a parameter without a
+// typed field is only restored while the typed parameters lack it, and the original
+// string of a typed parameter only while it still parses to the typed value.
+func restoreParameter(experimentType, name, value string, parameters map[string]string) bool {
+	current, currentOK := parameters[name]
+	spec := ChaosExperimentSpec{ExperimentType: experimentType}
+	if !setParameter(&spec, name, value) {
+		return !currentOK
+	}
+	original, originalOK := getParameters(&spec)[name]
+	return originalOK == currentOK && original == current
+}
+
+// setParameter sets a single typed parameter, reporting whether it has a typed field
+func setParameter(spec *ChaosExperimentSpec, name, value string) bool {
+	switch {
+	case spec.ExperimentType == ExperimentTypeNetworkLatency && (name == "latency" || name == "jitter"):
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return false
+		}
+		if spec.NetworkLatency == nil {
+			spec.NetworkLatency = &NetworkLatencyParameters{}
+		}
+		if name == "latency" {
+			spec.NetworkLatency.Latency = &metav1.Duration{Duration: duration}
+		} else {
+			spec.NetworkLatency.Jitter = &metav1.Duration{Duration: duration}
+		}
+		return true
+	case spec.ExperimentType == ExperimentTypeNetworkEmulation:
+		if spec.NetworkEmulation == nil {
+			spec.NetworkEmulation = &NetworkEmulationParameters{}
+		}
+		if !setNetworkEmulationParameter(spec.NetworkEmulation, name, value) {
+			if *spec.NetworkEmulation == (NetworkEmulationParameters{}) {
+				spec.NetworkEmulation = nil
+			}
+			return false
+		}
+		return true
+	case spec.ExperimentType == ExperimentTypeCPUHog && name == "cpuCores":
+		cores, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return false
+		}
+		spec.CPUHog = &CPUHogParameters{CPUCores: int32Ptr(int32(cores))}
+		return true
+	case spec.ExperimentType == ExperimentTypeMemoryHog && name == "memoryMB":
+		memoryMB, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return false
+		}
+		spec.MemoryHog = &MemoryHogParameters{MemoryMB: int32Ptr(int32(memoryMB))}
+		return true
+	default:
+		return false
+	}
+}
+
+// getParameters returns the typed parameters of the spec as v1alpha1 parameters
+func getParameters(spec *ChaosExperimentSpec) map[string]string {
+	parameters := map[string]string{}
+	if p := spec.NetworkLatency; p != nil {
+		if p.Latency != nil {
+			parameters["latency"] = p.Latency.Duration.String()
+		}
+		if p.Jitter != nil {
+			parameters["jitter"] = p.Jitter.Duration.String()
+		}
+	}
+	if p := spec.NetworkEmulation; p != nil {
+		if p.Latency != nil {
+			parameters["latency"] = p.Latency.Duration.String()
+		}
+		if p.Jitter != nil {
+			parameters["jitter"] = p.Jitter.Duration.String()
+		}
+		for name, field := range networkEmulationStringFields(p) {
+			if *field != "" {
+				parameters[name] = *field
+			}
+		}
+	}
+	if p := spec.CPUHog; p != nil && p.CPUCores != nil {
+		parameters["cpuCores"] = strconv.Itoa(int(*p.CPUCores))
+	}
+	if p := spec.MemoryHog; p != nil && p.MemoryMB != nil {
+		parameters["memoryMB"] = strconv.Itoa(int(*p.MemoryMB))
+	}
+	return parameters
+}
+
+// setNetworkEmulationParameter sets a single typed network-emulation parameter,
+// reporting whether it has a typed field
+func setNetworkEmulationParameter(p *NetworkEmulationParameters, name, value string) bool {
+	if name == "latency" || name == "jitter" {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return false
+		}
+		if name == "latency" {
+			p.Latency = &metav1.Duration{Duration: duration}
+		} else {
+			p.Jitter = &metav1.Duration{Duration: duration}
+		}
+		return true
+	}
+	field, ok := networkEmulationStringFields(p)[name]
+	if !ok {
+		return false
+	}
+	*field = value
+	return true
+}
+
+// networkEmulationStringFields returns the string fields of the network-emulation
+// parameters by their v1alpha1 parameter names
+func networkEmulationStringFields(p *NetworkEmulationParameters) map[string]*string {
+	return map[string]*string{
+		"interface":            &p.Interface,
+		"latencyCorrelation":   &p.LatencyCorrelation,
+		"loss":                 &p.Loss,
+		"lossCorrelation":      &p.LossCorrelation,
+		"duplicate":            &p.Duplicate,
+		"duplicateCorrelation": &p.DuplicateCorrelation,
+		"corrupt":              &p.Corrupt,
+		"corruptCorrelation":   &p.CorruptCorrelation,
+		"reorder":              &p.Reorder,
+		"reorderCorrelation":   &p.ReorderCorrelation,
+		"rate":                 &p.Rate,
+	}
+}
+
+func convertTargetFromV1alpha1(in v1alpha1.TargetResource) TargetResource {
+	out := TargetResource{
+		APIVersion: in.APIVersion,
+		Kind:       in.Kind,
+		Name:       in.Name,
+		Namespace:  in.Namespace,
+	}
+	if in.Selector != nil {
+		out.Selector = in.Selector.DeepCopy()
+	}
+	if in.NamespaceSelector != nil {
+		out.NamespaceSelector = in.NamespaceSelector.DeepCopy()
+	}
+	if in.ContainerNames != nil {
+		out.ContainerNames = append([]string(nil), in.ContainerNames...)
+	}
+	return out
+}
+
+func convertTargetToV1alpha1(in TargetResource) v1alpha1.TargetResource {
+	out := v1alpha1.TargetResource{
+		APIVersion: in.APIVersion,
+		Kind:       in.Kind,
+		Name:       in.Name,
+		Namespace:  in.Namespace,
+	}
+	if in.Selector != nil {
+		out.Selector = in.Selector.DeepCopy()
+	}
+	if in.NamespaceSelector != nil {
+		out.NamespaceSelector = in.NamespaceSelector.DeepCopy()
+	}
+	if in.ContainerNames != nil {
+		out.ContainerNames = append([]string(nil), in.ContainerNames...)
+	}
+	return out
+}
+
+func convertStatusFromV1alpha1(in v1alpha1.ChaosExperimentStatus) ChaosExperimentStatus {
+	in = *in.DeepCopy()
+	out := ChaosExperimentStatus{
+		Phase:              in.Phase,
+		StartTime:          in.StartTime,
+		EndTime:            in.EndTime,
+		Message:            in.Message,
+		Seed:               in.Seed,
+		ObservedGeneration: in.ObservedGeneration,
+		PausedAt:           in.PausedAt,
+		PausedDuration:     in.PausedDuration,
+		AbortedBy:          in.AbortedBy,
+		AbortReason:        in.AbortReason,
+		Result:             in.Result,
+		Conditions:         in.Conditions,
+	}
+	if in.SelectedPods != nil {
+		out.SelectedPods = make([]PodReference, 0, len(in.SelectedPods))
+		for _, pod := range in.SelectedPods {
+			out.SelectedPods = append(out.SelectedPods, PodReference(pod))
+		}
+	}
+	if in.Targets != nil {
+		out.Targets = make([]TargetStatus, 0, len(in.Targets))
+		for _, target := range in.Targets {
+			out.Targets = append(out.Targets, TargetStatus{
+				PodReference: PodReference(target.PodReference),
+				Containers:   target.Containers,
+				InjectedAt:   target.InjectedAt,
+				RecoveredAt:  target.RecoveredAt,
+				Error:        target.Error,
+			})
+		}
+	}
+	if in.Probes != nil {
+		out.Probes = make([]ProbeStatus, 0, len(in.Probes))
+		for _, probe := range in.Probes {
+			out.Probes = append(out.Probes, ProbeStatus(probe))
+		}
+	}
+	return out
+}
+
+func convertStatusToV1alpha1(in ChaosExperimentStatus) v1alpha1.ChaosExperimentStatus {
+	in = *in.DeepCopy()
+	out := v1alpha1.ChaosExperimentStatus{
+		Phase:              in.Phase,
+		StartTime:          in.StartTime,
+		EndTime:            in.EndTime,
+		Message:            in.Message,
+		Seed:               in.Seed,
+		ObservedGeneration: in.ObservedGeneration,
+		PausedAt:           in.PausedAt,
+		PausedDuration:     in.PausedDuration,
+		AbortedBy:          in.AbortedBy,
+		AbortReason:        in.AbortReason,
+		Result:             in.Result,
+		Conditions:         in.Conditions,
+	}
+	if in.SelectedPods != nil {
+		out.SelectedPods = make([]v1alpha1.PodReference, 0, len(in.SelectedPods))
+		for _, pod := range in.SelectedPods {
+			out.SelectedPods = append(out.SelectedPods, v1alpha1.PodReference(pod))
+		}
+	}
+	if in.Targets != nil {
+		out.Targets = make([]v1alpha1.TargetStatus, 0, len(in.Targets))
+		for _, target := range in.Targets {
+			out.Targets = append(out.Targets, v1alpha1.TargetStatus{
+				PodReference: v1alpha1.PodReference(target.PodReference),
+				Containers:   target.Containers,
+				InjectedAt:   target.InjectedAt,
+				RecoveredAt:  target.RecoveredAt,
+				Error:        target.Error,
+			})
+		}
+	}
+	if in.Probes != nil {
+		out.Probes = make([]v1alpha1.ProbeStatus, 0, len(in.Probes))
+		for _, probe := range in.Probes {
+			out.Probes = append(out.Probes, v1alpha1.ProbeStatus(probe))
+		}
+	}
+	return out
+}
+
+func convertSteadyStateFromV1alpha1(in *v1alpha1.SteadyState) *SteadyState {
+	if in == nil {
+		return nil
+	}
+	in = in.DeepCopy()
+	out := &SteadyState{Interval: in.Interval}
+	if in.Probes != nil {
+		out.Probes = make([]Probe, 0, len(in.Probes))
+		for _, probe := range in.Probes {
+			out.Probes = append(out.Probes, Probe{
+				Name:              probe.Name,
+				Timeout:           probe.Timeout,
+				AbortThreshold:    probe.AbortThreshold,
+				HTTP:              (*HTTPProbe)(probe.HTTP),
+				ResourceCondition: (*ResourceConditionProbe)(probe.ResourceCondition),
+				PodReadiness:      (*PodReadinessProbe)(probe.PodReadiness),
+				Exec:              (*ExecProbe)(probe.Exec),
+				Prometheus:        (*PrometheusProbe)(probe.Prometheus),
+			})
+		}
+	}
+	return out
+}
+
+func convertSteadyStateToV1alpha1(in *SteadyState) *v1alpha1.SteadyState {
+	if in == nil {
+		return nil
+	}
+	in = in.DeepCopy()
+	out := &v1alpha1.SteadyState{Interval: in.Interval}
+	if in.Probes != nil {
+		out.Probes = make([]v1alpha1.Probe, 0, len(in.Probes))
+		for _, probe := range in.Probes {
+			out.Probes = append(out.Probes, v1alpha1.Probe{
+				Name:              probe.Name,
+				Timeout:           probe.Timeout,
+				AbortThreshold:    probe.AbortThreshold,
+				HTTP:              (*v1alpha1.HTTPProbe)(probe.HTTP),
+				ResourceCondition: (*v1alpha1.ResourceConditionProbe)(probe.ResourceCondition),
+				PodReadiness:      (*v1alpha1.PodReadinessProbe)(probe.PodReadiness),
+				Exec:              (*v1alpha1.ExecProbe)(probe.Exec),
+				Prometheus:        (*v1alpha1.PrometheusProbe)(probe.Prometheus),
+			})
+		}
+	}
+	return out
+}
+
+func copyInt64(in *int64) *int64 {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
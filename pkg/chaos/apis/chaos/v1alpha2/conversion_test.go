@@ -0,0 +1,155 @@
+package v1alpha2
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var experimentTypes = []string{
+	ExperimentTypePodFailure,
+	ExperimentTypeNetworkLatency,
+	ExperimentTypeNetworkEmulation,
+	ExperimentTypeCPUHog,
+	ExperimentTypeMemoryHog,
+}
+
+// FuzzV1alpha1RoundTrip checks that converting a v1alpha1 experiment to v1alpha2
+// and back keeps its parameters exactly as they were written
+func FuzzV1alpha1RoundTrip(f *testing.F) {
+	f.Add(uint8(1), "latency", "1000ms", "jitter", "10ms")
+	f.Add(uint8(1), "latency", "1s", "jitter", "not a duration")
+	f.Add(uint8(2), "latency", "0.5s", "loss", "")
+	f.Add(uint8(2), "interface", "eth0", "unknown", "value")
+	f.Add(uint8(3), "cpuCores", "02", "", "")
+	f.Add(uint8(3), "cpuCores", "+1", "memoryMB", "256")
+	f.Add(uint8(4), "memoryMB", "99999999999", "", "")
+	f.Add(uint8(0), "latency", "100ms", "cpuCores", "1")
+
+	f.Fuzz(func(t *testing.T, experimentType uint8, name1, value1, name2, value2 string) {
+		// Objects reach the webhook as JSON, which only carries valid UTF-8
+		if !validUTF8(name1, value1, name2, value2) {
+			t.Skip()
+		}
+		in := &v1alpha1.ChaosExperiment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ChaosExperiment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "experiment", Namespace: "default"},
+			Spec: v1alpha1.ChaosExperimentSpec{
+				ExperimentType: experimentTypes[int(experimentType)%len(experimentTypes)],
+				Duration:       "1m",
+				Parameters:     map[string]string{name1: value1, name2: value2},
+			},
+		}
+
+		var hub ChaosExperiment
+		if err := ConvertFromV1alpha1(in, &hub); err != nil {
+			t.Fatalf("failed to convert from v1alpha1: %v", err)
+		}
+		var out v1alpha1.ChaosExperiment
+		if err := ConvertToV1alpha1(&hub, &out); err != nil {
+			t.Fatalf("failed to convert to v1alpha1: %v", err)
+		}
+		if !reflect.DeepEqual(in, &out) {
+			t.Errorf("round trip changed the experiment:\n got %#v\nwant %#v", out.Spec, in.Spec)
+		}
+	})
+}
+
+// FuzzV1alpha2RoundTrip checks that converting a v1alpha2 experiment to v1alpha1
+// and back keeps its typed parameters
+func FuzzV1alpha2RoundTrip(f *testing.F) {
+	f.Add(uint8(1), int64(time.Second), int64(0), uint8(1), int32(0), "", "", "")
+	f.Add(uint8(1), int64(1500*time.Millisecond), int64(time.Millisecond), uint8(3), int32(0), "", "", "")
+	f.Add(uint8(2), int64(time.Second), int64(0), uint8(1), int32(0), "eth1", "10%", "1mbit")
+	f.Add(uint8(2), int64(0), int64(0), uint8(0), int32(0), "", "5", "")
+	f.Add(uint8(3), int64(0), int64(0), uint8(0), int32(4), "", "", "")
+	f.Add(uint8(4), int64(0), int64(0), uint8(0), int32(-1), "", "", "")
+
+	f.Fuzz(func(t *testing.T, experimentType uint8, latency, jitter int64, durations uint8, size int32, iface, loss, rate string) {
+		if !validUTF8(iface, loss, rate) {
+			t.Skip()
+		}
+		in := &ChaosExperiment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: "ChaosExperiment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "experiment", Namespace: "default"},
+			Spec: ChaosExperimentSpec{
+				ExperimentType: experimentTypes[int(experimentType)%len(experimentTypes)],
+				Duration:       "1m",
+			},
+		}
+		// Only the parameters of the experiment type are set, as the CRD requires
+		var latencyParameter, jitterParameter *metav1.Duration
+		if durations&1 != 0 {
+			latencyParameter = &metav1.Duration{Duration: time.Duration(latency)}
+		}
+		if durations&2 != 0 {
+			jitterParameter = &metav1.Duration{Duration: time.Duration(jitter)}
+		}
+		switch in.Spec.ExperimentType {
+		case ExperimentTypeNetworkLatency:
+			if latencyParameter != nil || jitterParameter != nil {
+				in.Spec.NetworkLatency = &NetworkLatencyParameters{Latency: latencyParameter, Jitter: jitterParameter}
+			}
+		case ExperimentTypeNetworkEmulation:
+			p := NetworkEmulationParameters{Interface: iface, Latency: latencyParameter, Jitter: jitterParameter, Loss: loss, Rate: rate}
+			if p != (NetworkEmulationParameters{}) {
+				in.Spec.NetworkEmulation = &p
+			}
+		case ExperimentTypeCPUHog:
+			in.Spec.CPUHog = &CPUHogParameters{CPUCores: int32Ptr(size)}
+		case ExperimentTypeMemoryHog:
+			in.Spec.MemoryHog = &MemoryHogParameters{MemoryMB: int32Ptr(size)}
+		}
+
+		var spoke v1alpha1.ChaosExperiment
+		if err := ConvertToV1alpha1(in, &spoke); err != nil {
+			t.Fatalf("failed to convert to v1alpha1: %v", err)
+		}
+		var out ChaosExperiment
+		if err := ConvertFromV1alpha1(&spoke, &out); err != nil {
+			t.Fatalf("failed to convert from v1alpha1: %v", err)
+		}
+		if !reflect.DeepEqual(in, &out) {
+			t.Errorf("round trip changed the experiment:\n got %#v\nwant %#v", out.Spec, in.Spec)
+		}
+	})
+}
+
+// TestConvertToV1alpha1ChangedParameter checks that a typed parameter changed in
+// v1alpha2 replaces the original v1alpha1 string
+func TestConvertToV1alpha1ChangedParameter(t *testing.T) {
+	in := &v1alpha1.ChaosExperiment{
+		Spec: v1alpha1.ChaosExperimentSpec{
+			ExperimentType: ExperimentTypeNetworkLatency,
+			Parameters:     map[string]string{"latency": "1000ms", "jitter": "oops"},
+		},
+	}
+	var hub ChaosExperiment
+	if err := ConvertFromV1alpha1(in, &hub); err != nil {
+		t.Fatalf("failed to convert from v1alpha1: %v", err)
+	}
+	hub.Spec.NetworkLatency.Latency = &metav1.Duration{Duration: 2 * time.Second}
+	hub.Spec.NetworkLatency.Jitter = &metav1.Duration{Duration: 10 * time.Millisecond}
+
+	var out v1alpha1.ChaosExperiment
+	if err := ConvertToV1alpha1(&hub, &out); err != nil {
+		t.Fatalf("failed to convert to v1alpha1: %v", err)
+	}
+	want := map[string]string{"latency": "2s", "jitter": "10ms"}
+	if !reflect.DeepEqual(out.Spec.Parameters, want) {
+		t.Errorf("got parameters %v, want %v", out.Spec.Parameters, want)
+	}
+}
+
+func validUTF8(values ...string) bool {
+	for _, value := range values {
+		if !utf8.ValidString(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,4 @@
+// Package v1alpha2 contains the v1alpha2 version of the chaos.engineering API group.
+// +k8s:deepcopy-gen=package
+// +groupName=chaos.engineering
+package v1alpha2
@@ -0,0 +1,58 @@
+package daemon
+
+// DefaultPort is the port chaos daemons listen on
+const DefaultPort = 31767
+
+// DefaultServerName is the name the certificates of chaos daemons are issued for.
+// Clients call daemons by pod IP, so they verify this name instead.
+const DefaultServerName = "chaos-daemon.chaos-engineering.svc"
+
+// ExecPath is the path of the endpoint that runs operations in containers
+const ExecPath = "/exec"
+
+// The operations a chaos daemon runs. It builds their commands itself, so clients
+// cannot make it run anything else.
+const (
+	// OperationNetemStart replaces the root qdisc of an interface with netem
+	OperationNetemStart = "netem-start"
+	// OperationNetemStop removes the netem qdisc from an interface if it is in place
+	OperationNetemStop = "netem-stop"
+	// OperationStressStart starts stress in the background
+	OperationStressStart = "stress-start"
+	// OperationStressStop kills the stress processes
+	OperationStressStop = "stress-stop"
+)
+
+// Operation is a fault a chaos daemon injects or recovers. Fields that do not
+// apply to the operation must be left empty.
+type Operation struct {
+	// Name is one of the Operation constants
+	Name string `json:"name"`
+	// Interface is the network interface of netem operations
+	Interface string `json:"interface,omitempty"`
+	// Netem are the netem arguments of netem-start, e.g. delay 100000us loss 5%
+	Netem []string `json:"netem,omitempty"`
+	// CPUWorkers and MemoryMB are what stress-start hogs, exactly one of them
+	CPUWorkers int `json:"cpuWorkers,omitempty"`
+	MemoryMB   int `json:"memoryMB,omitempty"`
+	// TimeoutSeconds is how long stress-start runs
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// ExecRequest asks a chaos daemon to run an operation in the namespaces of a
+// container on its node
+type ExecRequest struct {
+	// ContainerID is the ID of the container as reported in the status of its
+	// pod, e.g. containerd://<id>
+	ContainerID string `json:"containerID"`
+	// Operation is run with the tools of the daemon image, in the network and PID
+	// namespaces and the cgroup of the container.
+	Operation Operation `json:"operation"`
+}
+
+// ExecResponse is the output of an operation that ran, whatever its exit code
+type ExecResponse struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exitCode"`
+}
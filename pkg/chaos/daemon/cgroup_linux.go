@@ -0,0 +1,18 @@
+//go:build linux
+
+package daemon
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// startInCgroup makes the command start in the cgroup, so that none of the
+// processes it forks escape it
+func startInCgroup(cmd *exec.Cmd, cgroup *os.File) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		UseCgroupFD: true,
+		CgroupFD:    int(cgroup.Fd()),
+	}
+}
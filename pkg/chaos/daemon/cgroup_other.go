@@ -0,0 +1,11 @@
+//go:build !linux
+
+package daemon
+
+import (
+	"os"
+	"os/exec"
+)
+
+// startInCgroup does nothing, as only Linux can start processes in a cgroup
+func startInCgroup(cmd *exec.Cmd, cgroup *os.File) {}
@@ -0,0 +1,70 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxResponseSize limits how much of a response body is read
+const maxResponseSize = 10 << 20
+
+// Client calls the API of chaos daemons
+type Client struct {
+	httpClient *http.Client
+	port       int
+	token      string
+}
+
+// NewClient creates a client for daemons listening on the port that accept the token.
+// Daemons serve over TLS, so the HTTP client must trust the CA that signed their certificate.
+func NewClient(httpClient *http.Client, port int, token string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		port:       port,
+		token:      token,
+	}
+}
+
+// Exec asks the daemon at the given address to run an operation and returns its
+// output. An operation that exits with a non-zero code is not an error.
+func (c *Client) Exec(ctx context.Context, address string, request ExecRequest) (ExecResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return ExecResponse{}, fmt.Errorf("failed to encode exec request: %v", err)
+	}
+
+	url := "https://" + net.JoinHostPort(address, strconv.Itoa(c.port)) + ExecPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return ExecResponse{}, fmt.Errorf("invalid request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return ExecResponse{}, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return ExecResponse{}, fmt.Errorf("failed to read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return ExecResponse{}, fmt.Errorf("daemon returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var response ExecResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return ExecResponse{}, fmt.Errorf("invalid exec response: %v", err)
+	}
+	return response, nil
+}
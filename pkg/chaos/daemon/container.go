@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// containerIDPattern matches the IDs containerd, CRI-O and Docker give containers
+var containerIDPattern = regexp.MustCompile(`^[0-9a-f]{12,64}$`)
+
+// parseContainerID strips the runtime scheme from the ID of a container in a pod status
+func parseContainerID(containerID string) (string, error) {
+	if i := strings.Index(containerID, "://"); i >= 0 {
+		containerID = containerID[i+len("://"):]
+	}
+	if !containerIDPattern.MatchString(containerID) {
+		return "", fmt.Errorf("invalid container ID %q", containerID)
+	}
+	return containerID, nil
+}
+
+// isContainerCgroup reports whether a cgroup directory name is the one the runtime
+// created for the container: the bare ID with the cgroupfs driver, or a scope such as
+// cri-containerd-<id>.scope, crio-<id>.scope or docker-<id>.scope with the systemd
+// driver. CRI-O also names the scope of the conmon monitor after the container, but
+// conmon runs in the namespaces of the host.
+func isContainerCgroup(name, id string) bool {
+	name = strings.TrimSuffix(name, ".scope")
+	if name == id {
+		return true
+	}
+	return strings.HasSuffix(name, "-"+id) && !strings.Contains(name, "conmon")
+}
+
+// findProcess returns the lowest PID of the processes of the container, found through
+// the cgroups they belong to, so that it works with any container runtime. procRoot
+// must be the proc filesystem of the host PID namespace.
+func findProcess(procRoot, id string) (int, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list processes: %v", err)
+	}
+
+	found := 0
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || (found != 0 && pid >= found) {
+			continue
+		}
+		// The process may have exited since the directory was listed
+		data, err := os.ReadFile(filepath.Join(procRoot, entry.Name(), "cgroup"))
+		if err != nil {
+			continue
+		}
+		if inContainerCgroup(string(data), id) {
+			found = pid
+		}
+	}
+	if found == 0 {
+		return 0, fmt.Errorf("no process of container %s is running on this node", id)
+	}
+	return found, nil
+}
+
+// inContainerCgroup reports whether the content of /proc/<pid>/cgroup places the
+// process in a cgroup of the container
+func inContainerCgroup(cgroups, id string) bool {
+	for _, line := range strings.Split(cgroups, "\n") {
+		// Each line is hierarchy-ID:controllers:path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		for _, name := range strings.Split(parts[2], "/") {
+			if isContainerCgroup(name, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findCgroup returns the directory of the cgroup of the container under cgroupRoot,
+// which must be the root of the unified (v2) hierarchy of the host
+func findCgroup(cgroupRoot, id string) (string, error) {
+	var dir string
+	err := filepath.WalkDir(cgroupRoot, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Cgroups of exited containers may be removed during the walk
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if isContainerCgroup(entry.Name(), id) {
+			dir = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search cgroups: %v", err)
+	}
+	if dir == "" {
+		return "", fmt.Errorf("no cgroup of container %s exists on this node", id)
+	}
+	return dir, nil
+}
+
+// isUnifiedCgroup reports whether cgroupRoot is the root of a cgroup v2 hierarchy
+func isUnifiedCgroup(cgroupRoot string) bool {
+	_, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers"))
+	return err == nil
+}
@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// interfacePattern matches the names of Linux network interfaces
+	interfacePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+	// netemDurationPattern matches the microseconds netem delays are given in
+	netemDurationPattern = regexp.MustCompile(`^[0-9]+us$`)
+	// netemPercentPattern matches netem percentages such as 5% or 0.5%
+	netemPercentPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?%$`)
+	// netemRatePattern matches the rates tc accepts, e.g. 100kbit or 1.5mbps
+	netemRatePattern = regexp.MustCompile(`^(?i)[0-9]+(\.[0-9]+)?([kmgt]i?)?(bit|bps)$`)
+)
+
+// netemParameters are the netem parameters a daemon accepts, with the patterns of
+// the values that may follow each of them. Only the first value is required.
+var netemParameters = map[string][]*regexp.Regexp{
+	"delay":     {netemDurationPattern, netemDurationPattern, netemPercentPattern},
+	"loss":      {netemPercentPattern, netemPercentPattern},
+	"duplicate": {netemPercentPattern, netemPercentPattern},
+	"corrupt":   {netemPercentPattern, netemPercentPattern},
+	"reorder":   {netemPercentPattern, netemPercentPattern},
+	"rate":      {netemRatePattern},
+}
+
+// Command validates the operation and returns the command that runs it
+func (o Operation) Command() ([]string, error) {
+	switch o.Name {
+	case OperationNetemStart:
+		if err := o.validateInterface(); err != nil {
+			return nil, err
+		}
+		if err := validateNetem(o.Netem); err != nil {
+			return nil, err
+		}
+		return append([]string{"tc", "qdisc", "replace", "dev", o.Interface, "root", "netem"}, o.Netem...), nil
+	case OperationNetemStop:
+		if err := o.validateInterface(); err != nil {
+			return nil, err
+		}
+		// The interface is passed as an argument rather than written into the script
+		return []string{
+			"sh", "-c",
+			`if tc qdisc show dev "$1" | grep -q netem; then tc qdisc del dev "$1" root; fi`,
+			"sh", o.Interface,
+		}, nil
+	case OperationStressStart:
+		if o.TimeoutSeconds <= 0 {
+			return nil, fmt.Errorf("stress timeout must be positive, got %d", o.TimeoutSeconds)
+		}
+		var args []string
+		switch {
+		case o.CPUWorkers > 0 && o.MemoryMB == 0:
+			args = []string{"--cpu", strconv.Itoa(o.CPUWorkers)}
+		case o.MemoryMB > 0 && o.CPUWorkers == 0:
+			args = []string{"--vm", "1", "--vm-bytes", strconv.Itoa(o.MemoryMB) + "M"}
+		default:
+			return nil, fmt.Errorf("stress needs either a positive number of CPU workers or of megabytes")
+		}
+		args = append(args, "--timeout", strconv.Itoa(o.TimeoutSeconds)+"s")
+		// stress runs in the background so that the operation returns right away
+		// instead of lasting the whole experiment
+		return append([]string{"sh", "-c", `nohup stress "$@" >/dev/null 2>&1 &`, "stress"}, args...), nil
+	case OperationStressStop:
+		return []string{"sh", "-c", "pkill stress || true"}, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", o.Name)
+	}
+}
+
+func (o Operation) validateInterface() error {
+	if !interfacePattern.MatchString(o.Interface) {
+		return fmt.Errorf("invalid network interface %q", o.Interface)
+	}
+	return nil
+}
+
+// validateNetem checks that the arguments are netem parameters, each followed by
+// the values it takes
+func validateNetem(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("netem has no parameters")
+	}
+	for i := 0; i < len(args); {
+		patterns, ok := netemParameters[args[i]]
+		if !ok {
+			return fmt.Errorf("unsupported netem parameter %q", args[i])
+		}
+		name := args[i]
+		i++
+		for j, pattern := range patterns {
+			if i < len(args) && pattern.MatchString(args[i]) {
+				i++
+				continue
+			}
+			if j == 0 {
+				return fmt.Errorf("netem parameter %s has no valid value", name)
+			}
+			break
+		}
+	}
+	return nil
+}
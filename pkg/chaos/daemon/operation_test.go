@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOperationCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation Operation
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:      "netem start",
+			operation: Operation{Name: OperationNetemStart, Interface: "eth0", Netem: []string{"delay", "100000us", "10000us", "25%", "loss", "5%", "rate", "1mbit"}},
+			want:      []string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem", "delay", "100000us", "10000us", "25%", "loss", "5%", "rate", "1mbit"},
+		},
+		{
+			name:      "netem stop",
+			operation: Operation{Name: OperationNetemStop, Interface: "eth0"},
+			want:      []string{"sh", "-c", `if tc qdisc show dev "$1" | grep -q netem; then tc qdisc del dev "$1" root; fi`, "sh", "eth0"},
+		},
+		{
+			name:      "cpu stress",
+			operation: Operation{Name: OperationStressStart, CPUWorkers: 2, TimeoutSeconds: 90},
+			want:      []string{"sh", "-c", `nohup stress "$@" >/dev/null 2>&1 &`, "stress", "--cpu", "2", "--timeout", "90s"},
+		},
+		{
+			name:      "memory stress",
+			operation: Operation{Name: OperationStressStart, MemoryMB: 256, TimeoutSeconds: 60},
+			want:      []string{"sh", "-c", `nohup stress "$@" >/dev/null 2>&1 &`, "stress", "--vm", "1", "--vm-bytes", "256M", "--timeout", "60s"},
+		},
+		{
+			name:      "stress stop",
+			operation: Operation{Name: OperationStressStop},
+			want:      []string{"sh", "-c", "pkill stress || true"},
+		},
+		{
+			name:      "unknown operation",
+			operation: Operation{Name: "exec"},
+			wantErr:   `unknown operation "exec"`,
+		},
+		{
+			name:      "interface with shell syntax",
+			operation: Operation{Name: OperationNetemStop, Interface: "eth0;reboot"},
+			wantErr:   "invalid network interface",
+		},
+		{
+			name:      "tc arguments beyond netem",
+			operation: Operation{Name: OperationNetemStart, Interface: "eth0", Netem: []string{"delay", "100us", "limit", "1"}},
+			wantErr:   `unsupported netem parameter "limit"`,
+		},
+		{
+			name:      "netem parameter without value",
+			operation: Operation{Name: OperationNetemStart, Interface: "eth0", Netem: []string{"loss"}},
+			wantErr:   "netem parameter loss has no valid value",
+		},
+		{
+			name:      "netem without parameters",
+			operation: Operation{Name: OperationNetemStart, Interface: "eth0"},
+			wantErr:   "netem has no parameters",
+		},
+		{
+			name:      "stress of both CPU and memory",
+			operation: Operation{Name: OperationStressStart, CPUWorkers: 1, MemoryMB: 256, TimeoutSeconds: 60},
+			wantErr:   "either a positive number of CPU workers or of megabytes",
+		},
+		{
+			name:      "stress without timeout",
+			operation: Operation{Name: OperationStressStart, CPUWorkers: 1},
+			wantErr:   "stress timeout must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.operation.Command()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("invalid operation: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got command %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
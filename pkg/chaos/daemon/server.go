@@ -0,0 +1,158 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// commandTimeout bounds how long the command of an operation may run. Operations
+// that last the whole experiment, like stress, run in the background.
+const commandTimeout = 2 * time.Minute
+
+// maxRequestSize limits how much of a request body is read
+const maxRequestSize = 1 << 20
+
+// Server serves the API of a chaos daemon. It runs the known operations with the tools
+// of its own image in the namespaces of containers on its node, so it must run
+// privileged in the PID namespace of the host.
+type Server struct {
+	mux   *http.ServeMux
+	token string
+	// procRoot is the proc filesystem of the host PID namespace
+	procRoot string
+	// cgroupRoot is the root of the cgroup hierarchy of the host
+	cgroupRoot string
+}
+
+// NewServer creates a daemon server that only accepts requests bearing the token
+func NewServer(token, procRoot, cgroupRoot string) *Server {
+	s := &Server{
+		mux:        http.NewServeMux(),
+		token:      token,
+		procRoot:   procRoot,
+		cgroupRoot: cgroupRoot,
+	}
+	s.mux.HandleFunc(ExecPath, s.serveExec)
+	s.mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// serveExec runs the operation of an exec request and writes back its output
+func (s *Server) serveExec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request ExecRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode exec request: %v", err), http.StatusBadRequest)
+		return
+	}
+	command, err := request.Operation.Command()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid operation: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	response, err := s.exec(r.Context(), request.ContainerID, command)
+	if err != nil {
+		klog.Errorf("Failed to run %s in container %s: %v", request.Operation.Name, request.ContainerID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	klog.V(2).Infof("Ran %s in container %s: exit code %d", request.Operation.Name, request.ContainerID, response.ExitCode)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		klog.Errorf("Failed to write exec response: %v", err)
+	}
+}
+
+// authorized reports whether the request bears the token of the daemon
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// exec runs a command in the network and PID namespaces of a container. On hosts
+// with cgroup v2 the command also starts in the cgroup of the container, so that
+// the resources it uses count against the limits of the container.
+func (s *Server) exec(ctx context.Context, containerID string, command []string) (ExecResponse, error) {
+	id, err := parseContainerID(containerID)
+	if err != nil {
+		return ExecResponse{}, err
+	}
+	pid, err := findProcess(s.procRoot, id)
+	if err != nil {
+		return ExecResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	// nsenter forks into the PID namespace, and unshare mounts a proc filesystem
+	// of that namespace so that tools like pkill only see the processes of the
+	// container. The root filesystem stays the one of the daemon image.
+	args := append([]string{
+		"--target", strconv.Itoa(pid), "--net", "--pid", "--",
+		"unshare", "--mount-proc", "--",
+	}, command...)
+	cmd := exec.CommandContext(ctx, "nsenter", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if isUnifiedCgroup(s.cgroupRoot) {
+		dir, err := findCgroup(s.cgroupRoot, id)
+		if err != nil {
+			return ExecResponse{}, err
+		}
+		cgroup, err := os.Open(dir)
+		if err != nil {
+			return ExecResponse{}, fmt.Errorf("failed to open cgroup of container %s: %v", id, err)
+		}
+		defer cgroup.Close()
+		startInCgroup(cmd, cgroup)
+	} else {
+		klog.V(2).Infof("Cgroup v1 host: running command in the cgroup of the daemon instead of container %s", id)
+	}
+
+	err = cmd.Run()
+	response := ExecResponse{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		response.ExitCode = exitErr.ExitCode()
+		return response, nil
+	}
+	if err != nil {
+		return response, fmt.Errorf("failed to run command: %v", err)
+	}
+	return response, nil
+}
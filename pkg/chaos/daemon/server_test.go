@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const testToken = "secret"
+
+// newTestClient returns a client for a daemon server over TLS, and the address of the server
+func newTestClient(t *testing.T, token string) (*Client, string) {
+	t.Helper()
+	server := httptest.NewTLSServer(NewServer(testToken, t.TempDir(), t.TempDir()))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(server.Client(), p, token), host
+}
+
+func TestExecRejectsUnknownOperations(t *testing.T) {
+	client, address := newTestClient(t, testToken)
+
+	_, err := client.Exec(context.Background(), address, ExecRequest{
+		ContainerID: "containerd://0123456789ab",
+		Operation:   Operation{Name: "exec"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "status 400") || !strings.Contains(err.Error(), `unknown operation "exec"`) {
+		t.Errorf("got error %v, want the unknown operation to be rejected", err)
+	}
+}
+
+func TestExecRejectsCommands(t *testing.T) {
+	client, address := newTestClient(t, testToken)
+
+	// Requests of clients that still send commands carry no operation
+	body := `{"containerID":"containerd://0123456789ab","command":["sh","-c","reboot"]}`
+	req, err := http.NewRequest(http.MethodPost, "https://"+net.JoinHostPort(address, strconv.Itoa(client.port))+ExecPath, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestExecRequiresToken(t *testing.T) {
+	client, address := newTestClient(t, "wrong")
+
+	_, err := client.Exec(context.Background(), address, ExecRequest{
+		ContainerID: "containerd://0123456789ab",
+		Operation:   Operation{Name: OperationStressStop},
+	})
+	if err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("got error %v, want the request to be unauthorized", err)
+	}
+}
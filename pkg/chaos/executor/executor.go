@@ -0,0 +1,63 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultContainerAnnotation names the container that commands run in when a
+// pod has several containers, as with kubectl exec
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// Request is a command to run in a container of a pod
+type Request struct {
+	Namespace string
+	Pod       string
+	// Container to run the command in. When empty the API server picks the
+	// only container of the pod and rejects pods with several containers.
+	Container string
+	Command   []string
+}
+
+// Result is the output of a command
+type Result struct {
+	Stdout string
+	Stderr string
+	// ExitCode is the status the command exited with
+	ExitCode int
+}
+
+// ExitError is returned when a command exits with a non-zero status
+type ExitError struct {
+	ExitCode int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command terminated with exit code %d", e.ExitCode)
+}
+
+// PodExecutor runs commands in the containers of pods
+type PodExecutor interface {
+	// Exec runs a command and waits for it to exit, or until the context is done.
+	// The output captured so far is returned even when the command fails; an
+	// *ExitError is returned when it exits with a non-zero status.
+	Exec(ctx context.Context, req Request) (Result, error)
+}
+
+// DefaultContainer returns the container of a pod that commands run in when
+// none is chosen: the one named by DefaultContainerAnnotation, or else the first
+func DefaultContainer(pod *corev1.Pod) string {
+	if name := pod.Annotations[DefaultContainerAnnotation]; name != "" {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == name {
+				return name
+			}
+		}
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
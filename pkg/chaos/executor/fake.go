@@ -0,0 +1,45 @@
+package executor
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeExecutor records the commands it is asked to run instead of running them.
+// It is meant for tests.
+type FakeExecutor struct {
+	mu       sync.Mutex
+	requests []Request
+
+	// Handler returns the result of a command. When nil, every command
+	// succeeds without output.
+	Handler func(req Request) (Result, error)
+}
+
+// NewFakeExecutor creates an executor that answers commands with the handler
+func NewFakeExecutor(handler func(req Request) (Result, error)) *FakeExecutor {
+	return &FakeExecutor{Handler: handler}
+}
+
+// Exec records the command and answers it with the handler
+func (f *FakeExecutor) Exec(ctx context.Context, req Request) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	if f.Handler == nil {
+		return Result{}, nil
+	}
+	return f.Handler(req)
+}
+
+// Requests returns the commands run so far, in order
+func (f *FakeExecutor) Requests() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Request(nil), f.requests...)
+}
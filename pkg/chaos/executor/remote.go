@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// DefaultTimeout bounds a command run by a RemoteExecutor whose context has no
+// earlier deadline
+const DefaultTimeout = 2 * time.Minute
+
+// RemoteExecutor runs commands through the exec subresource of the API server. It
+// streams over WebSocket and falls back to SPDY when the server cannot upgrade the
+// connection to WebSocket.
+type RemoteExecutor struct {
+	client  kubernetes.Interface
+	config  *rest.Config
+	timeout time.Duration
+}
+
+// NewRemoteExecutor creates an executor that talks to the API server of the client
+func NewRemoteExecutor(client kubernetes.Interface, config *rest.Config) *RemoteExecutor {
+	return &RemoteExecutor{
+		client:  client,
+		config:  config,
+		timeout: DefaultTimeout,
+	}
+}
+
+// Exec runs a command in a container of a pod
+func (e *RemoteExecutor) Exec(ctx context.Context, req Request) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	url := e.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(req.Pod).
+		Namespace(req.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: req.Container,
+			Command:   req.Command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec).
+		URL()
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(e.config, "POST", url)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create SPDY executor: %v", err)
+	}
+	websocketExec, err := remotecommand.NewWebSocketExecutor(e.config, "GET", url.String())
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create WebSocket executor: %v", err)
+	}
+	exec, err := remotecommand.NewFallbackExecutor(websocketExec, spdyExec, httpstream.IsUpgradeFailure)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create executor: %v", err)
+	}
+
+	var stdout, stderr strings.Builder
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	result := Result{Stdout: stdout.String(), Stderr: stderr.String()}
+
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		result.ExitCode = exitErr.ExitStatus()
+		return result, &ExitError{ExitCode: result.ExitCode}
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return result, fmt.Errorf("command did not finish: %v", ctx.Err())
+		}
+		return result, err
+	}
+	return result, nil
+}
@@ -2,36 +2,84 @@ package experiments
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
 	"github.com/chaos-engineering/controller/pkg/chaos/experiments/cpu-hog"
 	"github.com/chaos-engineering/controller/pkg/chaos/experiments/memory-hog"
+	"github.com/chaos-engineering/controller/pkg/chaos/experiments/network-emulation"
 	"github.com/chaos-engineering/controller/pkg/chaos/experiments/network-latency"
 	"github.com/chaos-engineering/controller/pkg/chaos/experiments/pod-failure"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 )
 
 // ChaosExperiment is the interface that all chaos experiments must implement
 type ChaosExperiment interface {
-	// Start starts the chaos experiment
-	Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error
-	
-	// Stop stops the chaos experiment
-	Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error
+	// Start starts the chaos experiment on the given target pod
+	Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error
+
+	// Stop stops the chaos experiment on the given target pod
+	Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error
 }
 
+// Supported experiment types
+var ExperimentTypes = []string{"pod-failure", "network-latency", "network-emulation", "cpu-hog", "memory-hog"}
+
+// ContainerExperimentTypes are the experiment types that are injected into
+// containers of the target pods rather than acting on the whole pod
+var ContainerExperimentTypes = []string{"network-latency", "network-emulation", "cpu-hog", "memory-hog"}
+
 // ExperimentFactory creates a new chaos experiment based on the experiment type
-func ExperimentFactory(client kubernetes.Interface, config *rest.Config, experimentType string) ChaosExperiment {
+func ExperimentFactory(client kubernetes.Interface, injectors injector.Injectors, experimentType string) ChaosExperiment {
 	switch experimentType {
 	case "pod-failure":
 		return podfailure.NewPodFailureExperiment(client)
 	case "network-latency":
-		return networklatency.NewNetworkLatencyExperiment(client, config)
+		return networklatency.NewNetworkLatencyExperiment(injectors)
+	case "network-emulation":
+		return networkemulation.NewNetworkEmulationExperiment(injectors)
+	case "cpu-hog":
+		return cpuhog.NewCPUHogExperiment(injectors)
+	case "memory-hog":
+		return memoryhog.NewMemoryHogExperiment(injectors)
+	default:
+		return nil
+	}
+}
+
+// ValidateParameters checks the parameters of an experiment of the given type
+func ValidateParameters(experimentType string, parameters map[string]string) error {
+	switch experimentType {
+	case "pod-failure":
+		return podfailure.ValidateParameters(parameters)
+	case "network-latency":
+		return networklatency.ValidateParameters(parameters)
+	case "network-emulation":
+		return networkemulation.ValidateParameters(parameters)
+	case "cpu-hog":
+		return cpuhog.ValidateParameters(parameters)
+	case "memory-hog":
+		return memoryhog.ValidateParameters(parameters)
+	default:
+		return fmt.Errorf("unknown experiment type: %s", experimentType)
+	}
+}
+
+// DefaultParameters returns the parameters an experiment of the given type uses when they are unset
+func DefaultParameters(experimentType string) map[string]string {
+	switch experimentType {
+	case "pod-failure":
+		return podfailure.DefaultParameters()
+	case "network-latency":
+		return networklatency.DefaultParameters()
+	case "network-emulation":
+		return networkemulation.DefaultParameters()
 	case "cpu-hog":
-		return cpuhog.NewCPUHogExperiment(client, config)
+		return cpuhog.DefaultParameters()
 	case "memory-hog":
-		return memoryhog.NewMemoryHogExperiment(client, config)
+		return memoryhog.DefaultParameters()
 	default:
 		return nil
 	}
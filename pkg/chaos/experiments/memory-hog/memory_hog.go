@@ -3,132 +3,132 @@ package memoryhog
 import (
 	"context"
 	"fmt"
-	"strings"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/daemon"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
 )
 
+// DefaultMemoryMB is the memoryMB used when the experiment does not set it
+const DefaultMemoryMB = "256"
+
 // MemoryHogExperiment implements the memory hog chaos experiment
 type MemoryHogExperiment struct {
-	client kubernetes.Interface
-	config *rest.Config
+	injectors injector.Injectors
 }
 
 // NewMemoryHogExperiment creates a new memory hog experiment
-func NewMemoryHogExperiment(client kubernetes.Interface, config *rest.Config) *MemoryHogExperiment {
+func NewMemoryHogExperiment(injectors injector.Injectors) *MemoryHogExperiment {
 	return &MemoryHogExperiment{
-		client: client,
-		config: config,
+		injectors: injectors,
 	}
 }
 
-// Start starts the memory hog experiment
-func (e *MemoryHogExperiment) Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error {
-	// Get the target pod
-	pod, err := e.client.CoreV1().Pods(experiment.Spec.Target.Namespace).Get(ctx, experiment.Spec.Target.Name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get target pod: %v", err)
+// DefaultParameters returns the parameters of a memory hog experiment that are used when unset
+func DefaultParameters() map[string]string {
+	return map[string]string{
+		"memoryMB": DefaultMemoryMB,
 	}
+}
 
+// ValidateParameters checks the parameters of a memory hog experiment
+func ValidateParameters(parameters map[string]string) error {
+	if val, ok := parameters["memoryMB"]; ok {
+		memoryMB, err := strconv.Atoi(val)
+		if err != nil || memoryMB <= 0 {
+			return fmt.Errorf("memoryMB must be a positive integer, got %q", val)
+		}
+	}
+	return nil
+}
+
+// stopCommand kills the stress process
+var stopCommand = injector.Command{
+	Args: []string{
+		"sh",
+		"-c",
+		"pkill stress || true",
+	},
+	Operation: &daemon.Operation{Name: daemon.OperationStressStop},
+}
+
+// Start starts the memory hog experiment
+func (e *MemoryHogExperiment) Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
 	klog.Infof("Starting memory hog experiment on pod %s/%s", pod.Namespace, pod.Name)
 
 	// Get memory parameter
-	memoryMB := "256" // default
+	memoryMB := DefaultMemoryMB
 	if val, ok := experiment.Spec.Parameters["memoryMB"]; ok {
 		memoryMB = val
 	}
 
-	// Run stress command to hog memory
-	cmd := []string{
-		"sh",
-		"-c",
-		fmt.Sprintf("apt-get update && apt-get install -y stress && stress --vm 1 --vm-bytes %sM --timeout %s", 
-			memoryMB, 
-			experiment.Spec.Duration),
+	operation, err := stressOperation(memoryMB, experiment.Spec.Duration)
+	if err != nil {
+		return err
 	}
 
-	req := e.client.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod.Name).
-		Namespace(pod.Namespace).
-		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Command: cmd,
-			Stdin:   false,
-			Stdout:  true,
-			Stderr:  true,
-			TTY:     false,
-		}, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
-	if err != nil {
-		return fmt.Errorf("failed to create executor: %v", err)
+	// Run stress command to hog memory. It runs in the background so that the
+	// exec returns once stress is installed instead of lasting the whole experiment.
+	// Images that already have stress, like the ephemeral tools image, skip the install.
+	cmd := injector.Command{
+		Args: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf("(command -v stress >/dev/null || (apt-get update && apt-get install -y stress)) && { nohup stress --vm 1 --vm-bytes %sM --timeout %s >/dev/null 2>&1 & }",
+				memoryMB,
+				experiment.Spec.Duration),
+		},
+		Operation: operation,
 	}
 
-	var stdout, stderr strings.Builder
-	err = exec.Stream(remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
+	inj, err := e.injectors.Get(experiment.Spec.Injector)
 	if err != nil {
-		return fmt.Errorf("failed to execute command: %v, stderr: %s", err, stderr.String())
+		return err
+	}
+	containers := injector.Containers(pod, experiment.Spec.Target.ContainerNames)
+	if err := injector.InjectContainers(ctx, inj, pod, containers, cmd, stopCommand); err != nil {
+		return err
 	}
 
-	klog.Infof("Successfully started memory hog on pod %s/%s: %s", pod.Namespace, pod.Name, stdout.String())
+	klog.Infof("Successfully started memory hog on pod %s/%s (containers %v)", pod.Namespace, pod.Name, containers)
 	return nil
 }
 
 // Stop stops the memory hog experiment
-func (e *MemoryHogExperiment) Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error {
-	// Get the target pod
-	pod, err := e.client.CoreV1().Pods(experiment.Spec.Target.Namespace).Get(ctx, experiment.Spec.Target.Name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get target pod: %v", err)
-	}
-
+func (e *MemoryHogExperiment) Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
 	klog.Infof("Stopping memory hog experiment on pod %s/%s", pod.Namespace, pod.Name)
 
-	// Kill stress process
-	cmd := []string{
-		"sh",
-		"-c",
-		"pkill stress || true",
+	inj, err := e.injectors.Get(experiment.Spec.Injector)
+	if err != nil {
+		return err
+	}
+	containers := injector.Containers(pod, experiment.Spec.Target.ContainerNames)
+	if err := injector.RecoverContainers(ctx, inj, pod, containers, stopCommand); err != nil {
+		return err
 	}
 
-	req := e.client.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod.Name).
-		Namespace(pod.Namespace).
-		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Command: cmd,
-			Stdin:   false,
-			Stdout:  true,
-			Stderr:  true,
-			TTY:     false,
-		}, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	klog.Infof("Successfully stopped memory hog on pod %s/%s (containers %v)", pod.Namespace, pod.Name, containers)
+	return nil
+}
+
+// stressOperation returns the chaos daemon operation that runs stress like the command of Start
+func stressOperation(memoryMB, duration string) (*daemon.Operation, error) {
+	n, err := strconv.Atoi(memoryMB)
 	if err != nil {
-		return fmt.Errorf("failed to create executor: %v", err)
+		return nil, fmt.Errorf("invalid memoryMB %q: %v", memoryMB, err)
 	}
-
-	var stdout, stderr strings.Builder
-	err = exec.Stream(remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
+	timeout, err := time.ParseDuration(duration)
 	if err != nil {
-		return fmt.Errorf("failed to execute command: %v, stderr: %s", err, stderr.String())
+		return nil, fmt.Errorf("invalid duration %q: %v", duration, err)
 	}
-
-	klog.Infof("Successfully stopped memory hog on pod %s/%s: %s", pod.Namespace, pod.Name, stdout.String())
-	return nil
+	return &daemon.Operation{
+		Name:           daemon.OperationStressStart,
+		MemoryMB:       n,
+		TimeoutSeconds: int(math.Ceil(timeout.Seconds())),
+	}, nil
 }
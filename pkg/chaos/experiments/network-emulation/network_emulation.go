@@ -0,0 +1,153 @@
+package networkemulation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
+	"github.com/chaos-engineering/controller/pkg/chaos/netem"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// NetworkEmulationExperiment implements the network emulation chaos experiment, which
+// impairs the traffic of the target pods with any combination of the netem impairments
+type NetworkEmulationExperiment struct {
+	injectors injector.Injectors
+}
+
+// NewNetworkEmulationExperiment creates a new network emulation experiment
+func NewNetworkEmulationExperiment(injectors injector.Injectors) *NetworkEmulationExperiment {
+	return &NetworkEmulationExperiment{
+		injectors: injectors,
+	}
+}
+
+// DefaultParameters returns the parameters of a network emulation experiment that are used when unset
+func DefaultParameters() map[string]string {
+	return map[string]string{
+		"interface": netem.DefaultInterface,
+	}
+}
+
+// percentParameters are the parameters that hold a percentage, with the options they set
+func percentParameters(options *netem.Options) map[string]*float64 {
+	return map[string]*float64{
+		"latencyCorrelation":   &options.LatencyCorrelation,
+		"loss":                 &options.Loss,
+		"lossCorrelation":      &options.LossCorrelation,
+		"duplicate":            &options.Duplicate,
+		"duplicateCorrelation": &options.DuplicateCorrelation,
+		"corrupt":              &options.Corrupt,
+		"corruptCorrelation":   &options.CorruptCorrelation,
+		"reorder":              &options.Reorder,
+		"reorderCorrelation":   &options.ReorderCorrelation,
+	}
+}
+
+// ParseParameters returns the netem options set by the parameters of a network emulation experiment
+func ParseParameters(parameters map[string]string) (netem.Options, error) {
+	options := netem.Options{
+		Interface: netem.DefaultInterface,
+		Rate:      parameters["rate"],
+	}
+	if val, ok := parameters["interface"]; ok {
+		options.Interface = val
+	}
+
+	durations := map[string]*time.Duration{
+		"latency": &options.Latency,
+		"jitter":  &options.Jitter,
+	}
+	for name, duration := range durations {
+		val, ok := parameters[name]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil || d < 0 {
+			return netem.Options{}, fmt.Errorf("%s must be a non-negative duration, got %q", name, val)
+		}
+		*duration = d
+	}
+
+	for name, percent := range percentParameters(&options) {
+		val, ok := parameters[name]
+		if !ok {
+			continue
+		}
+		p, err := netem.ParsePercent(val)
+		if err != nil {
+			return netem.Options{}, fmt.Errorf("%s must be a percentage between 0 and 100, got %q", name, val)
+		}
+		*percent = p
+	}
+
+	if err := options.Validate(); err != nil {
+		return netem.Options{}, err
+	}
+	return options, nil
+}
+
+// ValidateParameters checks the parameters of a network emulation experiment, which
+// must set at least one impairment
+func ValidateParameters(parameters map[string]string) error {
+	options, err := ParseParameters(parameters)
+	if err != nil {
+		return err
+	}
+	if options.Latency == 0 && options.Loss == 0 && options.Duplicate == 0 &&
+		options.Corrupt == 0 && options.Reorder == 0 && options.Rate == "" {
+		return fmt.Errorf("at least one of latency, loss, duplicate, corrupt, reorder and rate must be set")
+	}
+	return nil
+}
+
+// Start starts the network emulation experiment
+func (e *NetworkEmulationExperiment) Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
+	klog.Infof("Starting network emulation experiment on pod %s/%s", pod.Namespace, pod.Name)
+
+	options, err := ParseParameters(experiment.Spec.Parameters)
+	if err != nil {
+		return err
+	}
+	cmd := netem.StartCommand(options)
+
+	inj, err := e.injectors.Get(experiment.Spec.Injector)
+	if err != nil {
+		return err
+	}
+	containers := injector.Containers(pod, experiment.Spec.Target.ContainerNames)
+	if err := injector.InjectContainers(ctx, inj, pod, containers, cmd, netem.StopCommand(options.Interface)); err != nil {
+		return err
+	}
+
+	klog.Infof("Successfully added netem %s on interface %s of pod %s/%s (containers %v)",
+		strings.Join(options.Args(), " "), options.Interface, pod.Namespace, pod.Name, containers)
+	return nil
+}
+
+// Stop stops the network emulation experiment
+func (e *NetworkEmulationExperiment) Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
+	klog.Infof("Stopping network emulation experiment on pod %s/%s", pod.Namespace, pod.Name)
+
+	options, err := ParseParameters(experiment.Spec.Parameters)
+	if err != nil {
+		return err
+	}
+
+	inj, err := e.injectors.Get(experiment.Spec.Injector)
+	if err != nil {
+		return err
+	}
+	containers := injector.Containers(pod, experiment.Spec.Target.ContainerNames)
+	if err := injector.RecoverContainers(ctx, inj, pod, containers, netem.StopCommand(options.Interface)); err != nil {
+		return err
+	}
+
+	klog.Infof("Successfully removed netem from interface %s of pod %s/%s (containers %v)", options.Interface, pod.Namespace, pod.Name, containers)
+	return nil
+}
@@ -0,0 +1,102 @@
+package networkemulation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/netem"
+)
+
+func TestParseParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		want       netem.Options
+		wantErr    string
+	}{
+		{
+			name:       "defaults",
+			parameters: map[string]string{},
+			want:       netem.Options{Interface: netem.DefaultInterface},
+		},
+		{
+			name: "delay with jitter and correlation",
+			parameters: map[string]string{
+				"interface":          "eth1",
+				"latency":            "100ms",
+				"jitter":             "10ms",
+				"latencyCorrelation": "25%",
+			},
+			want: netem.Options{Interface: "eth1", Latency: 100 * time.Millisecond, Jitter: 10 * time.Millisecond, LatencyCorrelation: 25},
+		},
+		{
+			name: "percentages and rate",
+			parameters: map[string]string{
+				"loss":            "10",
+				"lossCorrelation": "5",
+				"duplicate":       "1%",
+				"corrupt":         "0.5",
+				"rate":            "1mbit",
+			},
+			want: netem.Options{Interface: netem.DefaultInterface, Loss: 10, LossCorrelation: 5, Duplicate: 1, Corrupt: 0.5, Rate: "1mbit"},
+		},
+		{
+			name:       "invalid duration",
+			parameters: map[string]string{"latency": "100"},
+			wantErr:    `latency must be a non-negative duration, got "100"`,
+		},
+		{
+			name:       "negative duration",
+			parameters: map[string]string{"jitter": "-1ms"},
+			wantErr:    `jitter must be a non-negative duration, got "-1ms"`,
+		},
+		{
+			name:       "invalid percentage",
+			parameters: map[string]string{"loss": "150%"},
+			wantErr:    `loss must be a percentage between 0 and 100, got "150%"`,
+		},
+		{
+			name:       "reorder without latency",
+			parameters: map[string]string{"reorder": "25"},
+			wantErr:    "reorder requires a latency",
+		},
+		{
+			name:       "invalid rate",
+			parameters: map[string]string{"rate": "fast"},
+			wantErr:    "rate must be a bandwidth",
+		},
+		{
+			name:       "invalid interface",
+			parameters: map[string]string{"interface": "eth0 && reboot", "loss": "1"},
+			wantErr:    "interface must be a network interface name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseParameters(tt.parameters)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseParameters failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got options %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateParametersRequiresAnImpairment(t *testing.T) {
+	if err := ValidateParameters(map[string]string{"interface": "eth1"}); err == nil {
+		t.Error("expected an error without impairments")
+	}
+	if err := ValidateParameters(map[string]string{"rate": "100kbit"}); err != nil {
+		t.Errorf("ValidateParameters failed: %v", err)
+	}
+}
@@ -3,130 +3,104 @@ package networklatency
 import (
 	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
+	"github.com/chaos-engineering/controller/pkg/chaos/netem"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
 )
 
+// DefaultLatency is the latency used when the experiment does not set it
+const DefaultLatency = "100ms"
+
 // NetworkLatencyExperiment implements the network latency chaos experiment
 type NetworkLatencyExperiment struct {
-	client kubernetes.Interface
-	config *rest.Config
+	injectors injector.Injectors
 }
 
 // NewNetworkLatencyExperiment creates a new network latency experiment
-func NewNetworkLatencyExperiment(client kubernetes.Interface, config *rest.Config) *NetworkLatencyExperiment {
+func NewNetworkLatencyExperiment(injectors injector.Injectors) *NetworkLatencyExperiment {
 	return &NetworkLatencyExperiment{
-		client: client,
-		config: config,
+		injectors: injectors,
 	}
 }
 
-// Start starts the network latency experiment
-func (e *NetworkLatencyExperiment) Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error {
-	// Get the target pod
-	pod, err := e.client.CoreV1().Pods(experiment.Spec.Target.Namespace).Get(ctx, experiment.Spec.Target.Name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get target pod: %v", err)
+// DefaultParameters returns the parameters of a network latency experiment that are used when unset
+func DefaultParameters() map[string]string {
+	return map[string]string{
+		"latency": DefaultLatency,
 	}
+}
 
+// ValidateParameters checks the parameters of a network latency experiment
+func ValidateParameters(parameters map[string]string) error {
+	for _, name := range []string{"latency", "jitter"} {
+		val, ok := parameters[name]
+		if !ok {
+			continue
+		}
+		duration, err := time.ParseDuration(val)
+		if err != nil || duration < 0 {
+			return fmt.Errorf("%s must be a non-negative duration, got %q", name, val)
+		}
+	}
+	return nil
+}
+
+// stopCommand removes the network latency using tc
+var stopCommand = netem.StopCommand(netem.DefaultInterface)
+
+// Start starts the network latency experiment
+func (e *NetworkLatencyExperiment) Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
 	klog.Infof("Starting network latency experiment on pod %s/%s", pod.Namespace, pod.Name)
 
-	// Get latency parameter
-	latency := "100ms" // default
+	// Get latency and jitter parameters
+	options := netem.Options{Interface: netem.DefaultInterface}
+	latency := DefaultLatency
 	if val, ok := experiment.Spec.Parameters["latency"]; ok {
 		latency = val
 	}
+	var err error
+	if options.Latency, err = time.ParseDuration(latency); err != nil {
+		return fmt.Errorf("invalid latency %q: %v", latency, err)
+	}
+	if val, ok := experiment.Spec.Parameters["jitter"]; ok {
+		if options.Jitter, err = time.ParseDuration(val); err != nil {
+			return fmt.Errorf("invalid jitter %q: %v", val, err)
+		}
+	}
 
 	// Add network latency using tc
-	cmd := []string{
-		"sh",
-		"-c",
-		fmt.Sprintf("tc qdisc add dev eth0 root netem delay %s", latency),
-	}
+	cmd := netem.StartCommand(options)
 
-	req := e.client.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod.Name).
-		Namespace(pod.Namespace).
-		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Command: cmd,
-			Stdin:   false,
-			Stdout:  true,
-			Stderr:  true,
-			TTY:     false,
-		}, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	inj, err := e.injectors.Get(experiment.Spec.Injector)
 	if err != nil {
-		return fmt.Errorf("failed to create executor: %v", err)
+		return err
 	}
-
-	var stdout, stderr strings.Builder
-	err = exec.Stream(remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to execute command: %v, stderr: %s", err, stderr.String())
+	containers := injector.Containers(pod, experiment.Spec.Target.ContainerNames)
+	if err := injector.InjectContainers(ctx, inj, pod, containers, cmd, stopCommand); err != nil {
+		return err
 	}
 
-	klog.Infof("Successfully added network latency to pod %s/%s: %s", pod.Namespace, pod.Name, stdout.String())
+	klog.Infof("Successfully added network latency to pod %s/%s (containers %v)", pod.Namespace, pod.Name, containers)
 	return nil
 }
 
 // Stop stops the network latency experiment
-func (e *NetworkLatencyExperiment) Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error {
-	// Get the target pod
-	pod, err := e.client.CoreV1().Pods(experiment.Spec.Target.Namespace).Get(ctx, experiment.Spec.Target.Name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get target pod: %v", err)
-	}
-
+func (e *NetworkLatencyExperiment) Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
 	klog.Infof("Stopping network latency experiment on pod %s/%s", pod.Namespace, pod.Name)
 
-	// Remove network latency using tc
-	cmd := []string{
-		"sh",
-		"-c",
-		"tc qdisc del dev eth0 root",
-	}
-
-	req := e.client.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod.Name).
-		Namespace(pod.Namespace).
-		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Command: cmd,
-			Stdin:   false,
-			Stdout:  true,
-			Stderr:  true,
-			TTY:     false,
-		}, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	inj, err := e.injectors.Get(experiment.Spec.Injector)
 	if err != nil {
-		return fmt.Errorf("failed to create executor: %v", err)
+		return err
 	}
-
-	var stdout, stderr strings.Builder
-	err = exec.Stream(remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to execute command: %v, stderr: %s", err, stderr.String())
+	containers := injector.Containers(pod, experiment.Spec.Target.ContainerNames)
+	if err := injector.RecoverContainers(ctx, inj, pod, containers, stopCommand); err != nil {
+		return err
 	}
 
-	klog.Infof("Successfully removed network latency from pod %s/%s: %s", pod.Namespace, pod.Name, stdout.String())
+	klog.Infof("Successfully removed network latency from pod %s/%s (containers %v)", pod.Namespace, pod.Name, containers)
 	return nil
 }
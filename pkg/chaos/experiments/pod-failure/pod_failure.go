@@ -3,9 +3,9 @@ package podfailure
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
@@ -23,18 +23,22 @@ func NewPodFailureExperiment(client kubernetes.Interface) *PodFailureExperiment
 	}
 }
 
-// Start starts the pod failure experiment
-func (e *PodFailureExperiment) Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error {
-	// Get the target pod
-	pod, err := e.client.CoreV1().Pods(experiment.Spec.Target.Namespace).Get(ctx, experiment.Spec.Target.Name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get target pod: %v", err)
-	}
+// DefaultParameters returns the parameters of a pod failure experiment that are used when unset
+func DefaultParameters() map[string]string {
+	return map[string]string{}
+}
 
+// ValidateParameters checks the parameters of a pod failure experiment, which takes none
+func ValidateParameters(parameters map[string]string) error {
+	return nil
+}
+
+// Start starts the pod failure experiment
+func (e *PodFailureExperiment) Start(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
 	klog.Infof("Starting pod failure experiment on pod %s/%s", pod.Namespace, pod.Name)
 
 	// Delete the pod to simulate failure
-	err = e.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+	err := e.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete pod: %v", err)
 	}
@@ -44,8 +48,8 @@ func (e *PodFailureExperiment) Start(ctx context.Context, experiment *v1alpha1.C
 }
 
 // Stop stops the pod failure experiment
-func (e *PodFailureExperiment) Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment) error {
+func (e *PodFailureExperiment) Stop(ctx context.Context, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
 	// Nothing to do here, the pod will be recreated by its controller
-	klog.Infof("Pod failure experiment completed for %s/%s", experiment.Spec.Target.Namespace, experiment.Spec.Target.Name)
+	klog.Infof("Pod failure experiment completed for %s/%s", pod.Namespace, pod.Name)
 	return nil
 }
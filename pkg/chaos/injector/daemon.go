@@ -0,0 +1,125 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/daemon"
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultDaemonSelector is the label selector of the pods of the chaos daemon DaemonSet
+const DefaultDaemonSelector = "app=chaos-daemon"
+
+// DaemonInjector runs the operations of commands through the chaos daemon on the node of the target pod,
+// which enters the namespaces of the target container and runs them with its own
+// privileged tools, so the target container needs neither tools nor capabilities.
+type DaemonInjector struct {
+	client  kubernetes.Interface
+	daemons *daemon.Client
+	// namespace and selector find the pods of the chaos daemon
+	namespace string
+	selector  string
+}
+
+// NewDaemonInjector creates an injector that calls the daemons matching the selector in the namespace
+func NewDaemonInjector(client kubernetes.Interface, daemons *daemon.Client, namespace, selector string) *DaemonInjector {
+	return &DaemonInjector{
+		client:    client,
+		daemons:   daemons,
+		namespace: namespace,
+		selector:  selector,
+	}
+}
+
+// Run asks the chaos daemon on the node of the pod to run the operation of a command in a container of the pod
+func (i *DaemonInjector) Run(ctx context.Context, pod *corev1.Pod, container string, command Command) (executor.Result, error) {
+	if command.Operation == nil {
+		return executor.Result{}, fmt.Errorf("the chaos daemon does not support this command")
+	}
+
+	// The ID of the container changes when it restarts
+	current, err := i.client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return executor.Result{}, fmt.Errorf("failed to get pod: %v", err)
+	}
+	if current.UID != pod.UID {
+		return executor.Result{}, fmt.Errorf("pod %s/%s was replaced", pod.Namespace, pod.Name)
+	}
+
+	containerID, err := runningContainerID(current, container)
+	if err != nil {
+		return executor.Result{}, err
+	}
+	address, err := i.daemonAddress(ctx, current.Spec.NodeName)
+	if err != nil {
+		return executor.Result{}, err
+	}
+
+	response, err := i.daemons.Exec(ctx, address, daemon.ExecRequest{
+		ContainerID: containerID,
+		Operation:   *command.Operation,
+	})
+	if err != nil {
+		return executor.Result{}, fmt.Errorf("chaos daemon on node %s: %v", current.Spec.NodeName, err)
+	}
+
+	result := executor.Result{
+		Stdout:   response.Stdout,
+		Stderr:   response.Stderr,
+		ExitCode: response.ExitCode,
+	}
+	if response.ExitCode != 0 {
+		return result, &executor.ExitError{ExitCode: response.ExitCode}
+	}
+	return result, nil
+}
+
+// daemonAddress returns the IP of a ready chaos daemon pod on the node
+func (i *DaemonInjector) daemonAddress(ctx context.Context, node string) (string, error) {
+	if node == "" {
+		return "", fmt.Errorf("pod is not scheduled to a node")
+	}
+
+	pods, err := i.client.CoreV1().Pods(i.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: i.selector,
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list chaos daemons: %v", err)
+	}
+	for j := range pods.Items {
+		daemonPod := &pods.Items[j]
+		if daemonPod.Spec.NodeName == node && daemonPod.Status.PodIP != "" && podReady(daemonPod) {
+			return daemonPod.Status.PodIP, nil
+		}
+	}
+	return "", fmt.Errorf("no ready chaos daemon on node %s", node)
+}
+
+// runningContainerID returns the runtime ID of a running container of the pod
+func runningContainerID(pod *corev1.Pod, container string) (string, error) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != container {
+			continue
+		}
+		if status.State.Running == nil || status.ContainerID == "" {
+			return "", fmt.Errorf("container %s is not running", container)
+		}
+		return status.ContainerID, nil
+	}
+	return "", fmt.Errorf("container %s has no status", container)
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// DefaultToolsImage is the image of the ephemeral containers that run the
+// commands of experiments. It must provide sh, sleep, tc, stress and pkill.
+const DefaultToolsImage = "chaos-engineering/chaos-tools:latest"
+
+// toolsContainerPrefix prefixes the names of the ephemeral tools containers
+const toolsContainerPrefix = "chaos-tools-"
+
+// toolsContainerStartTimeout bounds how long a new tools container may take to start,
+// which includes pulling its image
+const toolsContainerStartTimeout = time.Minute
+
+// EphemeralInjector runs commands in an ephemeral container attached to the target
+// pod. The container targets the container to inject, so it shares its process
+// namespace as well as the network namespace of the pod, and brings its own tools,
+// so the target image is left untouched.
+//
+// Ephemeral containers cannot be removed from a pod, so the tools container keeps
+// running and is reused by later commands against the same container.
+type EphemeralInjector struct {
+	client   kubernetes.Interface
+	executor executor.PodExecutor
+	image    string
+	// pollInterval is how often the status of a new tools container is checked
+	pollInterval time.Duration
+}
+
+// NewEphemeralInjector creates an injector whose ephemeral containers run the image
+func NewEphemeralInjector(client kubernetes.Interface, podExecutor executor.PodExecutor, image string) *EphemeralInjector {
+	return &EphemeralInjector{
+		client:       client,
+		executor:     podExecutor,
+		image:        image,
+		pollInterval: time.Second,
+	}
+}
+
+// Run runs a command in the tools container that targets a container of a pod,
+// attaching the tools container first if there is none yet
+func (i *EphemeralInjector) Run(ctx context.Context, pod *corev1.Pod, container string, command Command) (executor.Result, error) {
+	name, err := i.ensureToolsContainer(ctx, pod, container, command.NetAdmin)
+	if err != nil {
+		return executor.Result{}, err
+	}
+
+	return i.executor.Exec(ctx, executor.Request{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: name,
+		Command:   command.Args,
+	})
+}
+
+// ensureToolsContainer returns the name of a running tools container that targets the
+// container, attaching a new one to the pod when none can be reused
+func (i *EphemeralInjector) ensureToolsContainer(ctx context.Context, pod *corev1.Pod, container string, netAdmin bool) (string, error) {
+	var name string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := i.client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if current.UID != pod.UID {
+			return fmt.Errorf("pod %s/%s was replaced", pod.Namespace, pod.Name)
+		}
+
+		if name = i.findToolsContainer(current, container, netAdmin); name != "" {
+			return nil
+		}
+
+		name = toolsContainerName(current)
+		klog.Infof("Attaching ephemeral container %s targeting container %s to pod %s/%s", name, container, pod.Namespace, pod.Name)
+		current.Spec.EphemeralContainers = append(current.Spec.EphemeralContainers, i.toolsContainer(name, container, netAdmin))
+		_, err = i.client.CoreV1().Pods(pod.Namespace).UpdateEphemeralContainers(ctx, pod.Name, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to attach ephemeral container: %v", err)
+	}
+
+	if err := i.waitForRunning(ctx, pod, name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// findToolsContainer returns the name of a tools container of the pod that targets the
+// container, runs the configured image, has the required capabilities and has not
+// terminated, or "" if there is none
+func (i *EphemeralInjector) findToolsContainer(pod *corev1.Pod, container string, netAdmin bool) string {
+	for _, ephemeral := range pod.Spec.EphemeralContainers {
+		if !strings.HasPrefix(ephemeral.Name, toolsContainerPrefix) || ephemeral.TargetContainerName != container ||
+			ephemeral.Image != i.image || (netAdmin && !hasNetAdmin(&ephemeral)) {
+			continue
+		}
+		if status := ephemeralContainerStatus(pod, ephemeral.Name); status != nil && status.State.Terminated != nil {
+			continue
+		}
+		return ephemeral.Name
+	}
+	return ""
+}
+
+// toolsContainer builds a tools container that targets the container and idles so
+// that commands can be run in it
+func (i *EphemeralInjector) toolsContainer(name, container string, netAdmin bool) corev1.EphemeralContainer {
+	ephemeral := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    i.image,
+			Command:                  []string{"sleep", "infinity"},
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: container,
+	}
+	if netAdmin {
+		ephemeral.SecurityContext = &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"NET_ADMIN"},
+			},
+		}
+	}
+	return ephemeral
+}
+
+// waitForRunning waits until the ephemeral container of the pod is running
+func (i *EphemeralInjector) waitForRunning(ctx context.Context, pod *corev1.Pod, name string) error {
+	err := wait.PollUntilContextTimeout(ctx, i.pollInterval, toolsContainerStartTimeout, true, func(ctx context.Context) (bool, error) {
+		current, err := i.client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		status := ephemeralContainerStatus(current, name)
+		if status == nil {
+			return false, nil
+		}
+		if terminated := status.State.Terminated; terminated != nil {
+			return false, fmt.Errorf("ephemeral container %s terminated: %s %s", name, terminated.Reason, terminated.Message)
+		}
+		return status.State.Running != nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("ephemeral container %s of pod %s/%s did not start: %v", name, pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// toolsContainerName returns a name for a new tools container that no container of the pod has
+func toolsContainerName(pod *corev1.Pod) string {
+	for n := len(pod.Spec.EphemeralContainers); ; n++ {
+		name := fmt.Sprintf("%s%d", toolsContainerPrefix, n)
+		if !hasEphemeralContainer(pod, name) {
+			return name
+		}
+	}
+}
+
+func hasEphemeralContainer(pod *corev1.Pod, name string) bool {
+	for _, ephemeral := range pod.Spec.EphemeralContainers {
+		if ephemeral.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNetAdmin(ephemeral *corev1.EphemeralContainer) bool {
+	if ephemeral.SecurityContext == nil || ephemeral.SecurityContext.Capabilities == nil {
+		return false
+	}
+	for _, capability := range ephemeral.SecurityContext.Capabilities.Add {
+		if capability == "NET_ADMIN" {
+			return true
+		}
+	}
+	return false
+}
+
+func ephemeralContainerStatus(pod *corev1.Pod, name string) *corev1.ContainerStatus {
+	for i := range pod.Status.EphemeralContainerStatuses {
+		if pod.Status.EphemeralContainerStatuses[i].Name == name {
+			return &pod.Status.EphemeralContainerStatuses[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package injector
+
+import (
+	"context"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ExecInjector runs commands in the target containers themselves, so the
+// containers must ship the tools the commands use
+type ExecInjector struct {
+	executor executor.PodExecutor
+}
+
+// NewExecInjector creates an injector that runs commands with the executor
+func NewExecInjector(podExecutor executor.PodExecutor) *ExecInjector {
+	return &ExecInjector{
+		executor: podExecutor,
+	}
+}
+
+// Run runs a command in a container of a pod
+func (i *ExecInjector) Run(ctx context.Context, pod *corev1.Pod, container string, command Command) (executor.Result, error) {
+	return i.executor.Exec(ctx, executor.Request{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: container,
+		Command:   command.Args,
+	})
+}
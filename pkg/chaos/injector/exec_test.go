@@ -0,0 +1,90 @@
+package injector
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	startCommand = Command{Args: []string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem", "delay", "100ms"}, NetAdmin: true}
+	stopCommand  = Command{Args: []string{"tc", "qdisc", "del", "dev", "eth0", "root"}, NetAdmin: true}
+)
+
+func testPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout-0", Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}, {Name: "proxy"}},
+		},
+	}
+}
+
+func TestExecInjectorRunsInContainer(t *testing.T) {
+	fake := executor.NewFakeExecutor(func(req executor.Request) (executor.Result, error) {
+		return executor.Result{Stdout: "ok"}, nil
+	})
+	injector := NewExecInjector(fake)
+
+	result, err := injector.Run(context.Background(), testPod(), "sidecar", startCommand)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Stdout != "ok" {
+		t.Errorf("got stdout %q, want %q", result.Stdout, "ok")
+	}
+	want := []executor.Request{{Namespace: "shop", Pod: "checkout-0", Container: "sidecar", Command: startCommand.Args}}
+	if got := fake.Requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got requests %v, want %v", got, want)
+	}
+}
+
+func TestInjectContainersRollsBackOnFailure(t *testing.T) {
+	fake := executor.NewFakeExecutor(func(req executor.Request) (executor.Result, error) {
+		if req.Container == "proxy" && reflect.DeepEqual(req.Command, startCommand.Args) {
+			return executor.Result{Stderr: "RTNETLINK answers: Operation not permitted"}, &executor.ExitError{ExitCode: 2}
+		}
+		return executor.Result{}, nil
+	})
+	pod := testPod()
+
+	err := InjectContainers(context.Background(), NewExecInjector(fake), pod, []string{"app", "sidecar", "proxy"}, startCommand, stopCommand)
+	if err == nil {
+		t.Fatalf("InjectContainers succeeded although proxy failed")
+	}
+	if !strings.Contains(err.Error(), "container proxy") || !strings.Contains(err.Error(), "Operation not permitted") {
+		t.Errorf("error %q does not name the container and its stderr", err)
+	}
+
+	// The containers injected before the failure are rolled back
+	var got []string
+	for _, req := range fake.Requests() {
+		got = append(got, req.Container+" "+req.Command[2])
+	}
+	want := []string{"app replace", "sidecar replace", "proxy replace", "app del", "sidecar del"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got commands %v, want %v", got, want)
+	}
+}
+
+func TestRecoverContainersContinuesAfterFailure(t *testing.T) {
+	fake := executor.NewFakeExecutor(func(req executor.Request) (executor.Result, error) {
+		if req.Container == "app" {
+			return executor.Result{}, &executor.ExitError{ExitCode: 1}
+		}
+		return executor.Result{}, nil
+	})
+
+	err := RecoverContainers(context.Background(), NewExecInjector(fake), testPod(), []string{"app", "sidecar"}, stopCommand)
+	if err == nil {
+		t.Errorf("RecoverContainers did not report the failure")
+	}
+	if n := len(fake.Requests()); n != 2 {
+		t.Errorf("got %d commands, want 2", n)
+	}
+}
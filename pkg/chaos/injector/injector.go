@@ -0,0 +1,91 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/daemon"
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// Command is a command that injects a fault into a container or recovers it
+type Command struct {
+	// Args are the command and its arguments
+	Args []string
+	// NetAdmin is whether the command needs the NET_ADMIN capability, e.g. to run tc
+	NetAdmin bool
+	// Operation is what the chaos daemon runs instead of Args, as it only runs
+	// known operations. Commands without one cannot use the daemon injector.
+	Operation *daemon.Operation
+}
+
+// Injector runs the commands of experiments against containers of target pods
+type Injector interface {
+	// Run runs a command against a container of a pod and waits for it to exit
+	Run(ctx context.Context, pod *corev1.Pod, container string, command Command) (executor.Result, error)
+}
+
+// Injectors holds the available injectors by the name experiments select them with
+type Injectors map[string]Injector
+
+// Get returns the injector with the given name; an empty name selects the exec injector
+func (i Injectors) Get(name string) (Injector, error) {
+	if name == "" {
+		name = v1alpha1.InjectorExec
+	}
+	injector, ok := i[name]
+	if !ok {
+		return nil, fmt.Errorf("injector %q is not available", name)
+	}
+	return injector, nil
+}
+
+// Containers returns the containers of a pod that commands run against: the
+// named ones, or the default container when none are named
+func Containers(pod *corev1.Pod, names []string) []string {
+	if len(names) > 0 {
+		return names
+	}
+	return []string{executor.DefaultContainer(pod)}
+}
+
+// InjectContainers runs the start command against each of the containers of a pod. When
+// it fails for one of them, the stop command is run against the containers already
+// injected before the error is returned, so that the pod is left as it was.
+func InjectContainers(ctx context.Context, injector Injector, pod *corev1.Pod, containers []string, start, stop Command) error {
+	for i, container := range containers {
+		if err := run(ctx, injector, pod, container, start); err != nil {
+			for _, injected := range containers[:i] {
+				if stopErr := run(ctx, injector, pod, injected, stop); stopErr != nil {
+					klog.Errorf("Failed to roll back container %s of pod %s/%s: %v", injected, pod.Namespace, pod.Name, stopErr)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// RecoverContainers runs the stop command against each of the containers of a pod, even
+// when it fails for some of them. It returns the last error, if any.
+func RecoverContainers(ctx context.Context, injector Injector, pod *corev1.Pod, containers []string, stop Command) error {
+	var lastErr error
+	for _, container := range containers {
+		if err := run(ctx, injector, pod, container, stop); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func run(ctx context.Context, injector Injector, pod *corev1.Pod, container string, command Command) error {
+	result, err := injector.Run(ctx, pod, container, command)
+	if err != nil {
+		return fmt.Errorf("failed to execute command in container %s: %v, stderr: %s", container, err, result.Stderr)
+	}
+	klog.V(2).Infof("Executed command in container %s of pod %s/%s: %s", container, pod.Namespace, pod.Name, result.Stdout)
+	return nil
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"context"
+)
+
+// Sample is a single value of the result of a query
+type Sample struct {
+	// Labels identify the series of the sample; they are empty for a scalar result
+	Labels map[string]string
+	// Value of the sample
+	Value float64
+}
+
+// Backend evaluates queries against a metrics store
+type Backend interface {
+	// Query evaluates an instant query at the current time and returns the samples of its result
+	Query(ctx context.Context, query string) ([]Sample, error)
+}
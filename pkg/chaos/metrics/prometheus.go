@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxResponseSize limits how much of a query response is read
+const maxResponseSize = 10 << 20
+
+// PrometheusBackend evaluates queries with the HTTP API of Prometheus or of any
+// compatible store, such as Thanos or VictoriaMetrics
+type PrometheusBackend struct {
+	address    string
+	httpClient *http.Client
+}
+
+// NewPrometheusBackend creates a backend for the API at the given base URL
+func NewPrometheusBackend(address string, httpClient *http.Client) *PrometheusBackend {
+	return &PrometheusBackend{
+		address:    strings.TrimSuffix(address, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// queryResponse is the envelope of a response of the query API
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// vectorSample is a sample of an instant vector result
+type vectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+// Query evaluates an instant query with GET /api/v1/query. Vector and scalar
+// results are supported.
+func (b *PrometheusBackend) Query(ctx context.Context, query string) ([]Sample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		b.address+"/api/v1/query?"+url.Values{"query": []string{query}}.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %v", err)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	// Errors are reported with a 4xx or 5xx status and an error envelope
+	var result queryResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("invalid response with status %d: %v", resp.StatusCode, err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("%s: %s (status %d)", result.ErrorType, result.Error, resp.StatusCode)
+	}
+
+	switch result.Data.ResultType {
+	case "vector":
+		var vector []vectorSample
+		if err := json.Unmarshal(result.Data.Result, &vector); err != nil {
+			return nil, fmt.Errorf("invalid vector result: %v", err)
+		}
+		samples := make([]Sample, 0, len(vector))
+		for _, s := range vector {
+			value, err := parseValue(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, Sample{Labels: s.Metric, Value: value})
+		}
+		return samples, nil
+	case "scalar":
+		var scalar [2]interface{}
+		if err := json.Unmarshal(result.Data.Result, &scalar); err != nil {
+			return nil, fmt.Errorf("invalid scalar result: %v", err)
+		}
+		value, err := parseValue(scalar)
+		if err != nil {
+			return nil, err
+		}
+		return []Sample{{Value: value}}, nil
+	}
+	return nil, fmt.Errorf("unsupported result type %q", result.Data.ResultType)
+}
+
+// parseValue parses the value of a [timestamp, "value"] pair
+func parseValue(pair [2]interface{}) (float64, error) {
+	s, ok := pair[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample value %q: %v", s, err)
+	}
+	return value, nil
+}
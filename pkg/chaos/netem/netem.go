@@ -0,0 +1,183 @@
+package netem
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/daemon"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
+)
+
+// DefaultInterface is the network interface of a pod that netem is applied to
+const DefaultInterface = "eth0"
+
+// interfacePattern matches the names of Linux network interfaces, which also
+// keeps them safe to use in shell commands
+var interfacePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+
+// ratePattern matches the rates tc accepts, e.g. 100kbit or 1.5mbps
+var ratePattern = regexp.MustCompile(`^(?i)[0-9]+(\.[0-9]+)?([kmgt]i?)?(bit|bps)$`)
+
+// Options are the impairments of a netem qdisc. Percentages are between 0 and
+// 100, and zero values leave an impairment out.
+type Options struct {
+	// Interface is the network interface to impair
+	Interface string
+	// Latency is the delay added to outgoing packets, varied by up to Jitter.
+	// LatencyCorrelation is how much each delay depends on the previous one.
+	Latency            time.Duration
+	Jitter             time.Duration
+	LatencyCorrelation float64
+	// Loss is the percentage of packets dropped
+	Loss            float64
+	LossCorrelation float64
+	// Duplicate is the percentage of packets sent twice
+	Duplicate            float64
+	DuplicateCorrelation float64
+	// Corrupt is the percentage of packets with a flipped bit
+	Corrupt            float64
+	CorruptCorrelation float64
+	// Reorder is the percentage of packets sent right away, ahead of the
+	// delayed ones, so it requires a latency
+	Reorder            float64
+	ReorderCorrelation float64
+	// Rate limits the bandwidth, e.g. 1mbit
+	Rate string
+}
+
+// Validate checks that the options make a valid netem qdisc
+func (o Options) Validate() error {
+	if !interfacePattern.MatchString(o.Interface) {
+		return fmt.Errorf("interface must be a network interface name, got %q", o.Interface)
+	}
+	if o.Latency < 0 || o.Jitter < 0 {
+		return fmt.Errorf("latency and jitter must not be negative")
+	}
+	if o.Rate != "" && !ratePattern.MatchString(o.Rate) {
+		return fmt.Errorf("rate must be a bandwidth such as 1mbit or 100kbps, got %q", o.Rate)
+	}
+
+	if o.LatencyCorrelation < 0 || o.LatencyCorrelation > 100 {
+		return fmt.Errorf("latencyCorrelation must be a percentage between 0 and 100")
+	}
+	// Correlations only apply alongside what they correlate
+	if o.LatencyCorrelation > 0 && o.Jitter == 0 {
+		return fmt.Errorf("latencyCorrelation requires a jitter")
+	}
+
+	percentages := []struct {
+		name        string
+		value       float64
+		correlation float64
+	}{
+		{"loss", o.Loss, o.LossCorrelation},
+		{"duplicate", o.Duplicate, o.DuplicateCorrelation},
+		{"corrupt", o.Corrupt, o.CorruptCorrelation},
+		{"reorder", o.Reorder, o.ReorderCorrelation},
+	}
+	for _, p := range percentages {
+		if p.value < 0 || p.value > 100 {
+			return fmt.Errorf("%s must be a percentage between 0 and 100", p.name)
+		}
+		if p.correlation < 0 || p.correlation > 100 {
+			return fmt.Errorf("%sCorrelation must be a percentage between 0 and 100", p.name)
+		}
+		if p.correlation > 0 && p.value == 0 {
+			return fmt.Errorf("%sCorrelation requires %s", p.name, p.name)
+		}
+	}
+	if o.Reorder > 0 && o.Latency == 0 {
+		return fmt.Errorf("reorder requires a latency")
+	}
+	return nil
+}
+
+// Args returns the arguments of the netem qdisc
+func (o Options) Args() []string {
+	var args []string
+	if o.Latency > 0 || o.Jitter > 0 {
+		args = append(args, "delay", formatDuration(o.Latency))
+		if o.Jitter > 0 {
+			args = append(args, formatDuration(o.Jitter))
+			if o.LatencyCorrelation > 0 {
+				args = append(args, formatPercent(o.LatencyCorrelation))
+			}
+		}
+	}
+	args = appendPercent(args, "loss", o.Loss, o.LossCorrelation)
+	args = appendPercent(args, "duplicate", o.Duplicate, o.DuplicateCorrelation)
+	args = appendPercent(args, "corrupt", o.Corrupt, o.CorruptCorrelation)
+	args = appendPercent(args, "reorder", o.Reorder, o.ReorderCorrelation)
+	if o.Rate != "" {
+		args = append(args, "rate", o.Rate)
+	}
+	return args
+}
+
+// StartCommand replaces the root qdisc of the interface with netem. Replacing it
+// keeps this idempotent when an experiment is started again after a restart.
+func StartCommand(o Options) injector.Command {
+	return injector.Command{
+		Args:     append([]string{"tc", "qdisc", "replace", "dev", o.Interface, "root", "netem"}, o.Args()...),
+		NetAdmin: true,
+		Operation: &daemon.Operation{
+			Name:      daemon.OperationNetemStart,
+			Interface: o.Interface,
+			Netem:     o.Args(),
+		},
+	}
+}
+
+// StopCommand removes the netem qdisc from the interface, only if it is still in
+// place so that stopping an already recovered pod succeeds. The interface must
+// have been validated.
+func StopCommand(iface string) injector.Command {
+	return injector.Command{
+		Args: []string{
+			"sh",
+			"-c",
+			fmt.Sprintf("if tc qdisc show dev %[1]s | grep -q netem; then tc qdisc del dev %[1]s root; fi", iface),
+		},
+		NetAdmin: true,
+		Operation: &daemon.Operation{
+			Name:      daemon.OperationNetemStop,
+			Interface: iface,
+		},
+	}
+}
+
+// ParsePercent parses a percentage between 0 and 100, with or without a % sign
+func ParsePercent(value string) (float64, error) {
+	if n := len(value); n > 0 && value[n-1] == '%' {
+		value = value[:n-1]
+	}
+	percent, err := strconv.ParseFloat(value, 64)
+	// Written so that NaN is rejected too
+	if err != nil || !(percent >= 0 && percent <= 100) {
+		return 0, fmt.Errorf("invalid percentage %q", value)
+	}
+	return percent, nil
+}
+
+func appendPercent(args []string, name string, value, correlation float64) []string {
+	if value == 0 {
+		return args
+	}
+	args = append(args, name, formatPercent(value))
+	if correlation > 0 {
+		args = append(args, formatPercent(correlation))
+	}
+	return args
+}
+
+// formatDuration formats a duration in the microseconds tc understands, as tc
+// does not parse durations such as 1m30s
+func formatDuration(d time.Duration) string {
+	return strconv.FormatInt(d.Microseconds(), 10) + "us"
+}
+
+func formatPercent(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', -1, 64) + "%"
+}
@@ -0,0 +1,153 @@
+package netem
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/daemon"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr string
+	}{
+		{name: "delay with jitter and correlation", options: Options{Interface: "eth0", Latency: 100 * time.Millisecond, Jitter: 10 * time.Millisecond, LatencyCorrelation: 25}},
+		{name: "percentages", options: Options{Interface: "eth0", Loss: 10, LossCorrelation: 5, Duplicate: 1, Corrupt: 0.5}},
+		{name: "reorder with latency", options: Options{Interface: "eth0", Latency: time.Millisecond, Reorder: 25}},
+		{name: "rate", options: Options{Interface: "eth0", Rate: "1.5Mibit"}},
+		{name: "rate in bytes", options: Options{Interface: "ens5", Rate: "100kbps"}},
+		{name: "empty interface", options: Options{}, wantErr: "interface must be a network interface name"},
+		{name: "interface too long", options: Options{Interface: "averylonginterface"}, wantErr: "interface must be a network interface name"},
+		{name: "interface with shell characters", options: Options{Interface: "eth0; reboot"}, wantErr: "interface must be a network interface name"},
+		{name: "negative latency", options: Options{Interface: "eth0", Latency: -time.Millisecond}, wantErr: "must not be negative"},
+		{name: "negative jitter", options: Options{Interface: "eth0", Latency: time.Millisecond, Jitter: -time.Millisecond}, wantErr: "must not be negative"},
+		{name: "rate without unit", options: Options{Interface: "eth0", Rate: "100"}, wantErr: "rate must be a bandwidth"},
+		{name: "rate with unknown unit", options: Options{Interface: "eth0", Rate: "1gb"}, wantErr: "rate must be a bandwidth"},
+		{name: "latency correlation without jitter", options: Options{Interface: "eth0", Latency: time.Millisecond, LatencyCorrelation: 10}, wantErr: "latencyCorrelation requires a jitter"},
+		{name: "latency correlation above 100", options: Options{Interface: "eth0", Jitter: time.Millisecond, LatencyCorrelation: 101}, wantErr: "latencyCorrelation must be a percentage"},
+		{name: "loss above 100", options: Options{Interface: "eth0", Loss: 100.5}, wantErr: "loss must be a percentage"},
+		{name: "negative duplicate", options: Options{Interface: "eth0", Duplicate: -1}, wantErr: "duplicate must be a percentage"},
+		{name: "corrupt correlation above 100", options: Options{Interface: "eth0", Corrupt: 1, CorruptCorrelation: 200}, wantErr: "corruptCorrelation must be a percentage"},
+		{name: "loss correlation without loss", options: Options{Interface: "eth0", LossCorrelation: 10}, wantErr: "lossCorrelation requires loss"},
+		{name: "reorder without latency", options: Options{Interface: "eth0", Reorder: 25}, wantErr: "reorder requires a latency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		want    []string
+	}{
+		{
+			name:    "no impairments",
+			options: Options{Interface: "eth0"},
+		},
+		{
+			name:    "delay",
+			options: Options{Latency: 100 * time.Millisecond},
+			want:    []string{"delay", "100000us"},
+		},
+		{
+			name:    "delay with jitter and correlation",
+			options: Options{Latency: 1500 * time.Microsecond, Jitter: 250 * time.Microsecond, LatencyCorrelation: 25},
+			want:    []string{"delay", "1500us", "250us", "25%"},
+		},
+		{
+			name:    "correlation is left out without jitter",
+			options: Options{Latency: time.Second, LatencyCorrelation: 25},
+			want:    []string{"delay", "1000000us"},
+		},
+		{
+			name:    "durations tc does not parse",
+			options: Options{Latency: time.Minute + 30*time.Second},
+			want:    []string{"delay", "90000000us"},
+		},
+		{
+			name:    "percentages",
+			options: Options{Loss: 10, LossCorrelation: 5, Duplicate: 1, Corrupt: 0.5, CorruptCorrelation: 12.5},
+			want:    []string{"loss", "10%", "5%", "duplicate", "1%", "corrupt", "0.5%", "12.5%"},
+		},
+		{
+			name:    "reorder and rate",
+			options: Options{Latency: 10 * time.Millisecond, Reorder: 25, ReorderCorrelation: 50, Rate: "1mbit"},
+			want:    []string{"delay", "10000us", "reorder", "25%", "50%", "rate", "1mbit"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.options.Args(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got args %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartCommand(t *testing.T) {
+	cmd := StartCommand(Options{Interface: "eth1", Latency: 20 * time.Millisecond, Loss: 2})
+
+	want := []string{"tc", "qdisc", "replace", "dev", "eth1", "root", "netem", "delay", "20000us", "loss", "2%"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("got args %q, want %q", cmd.Args, want)
+	}
+	if !cmd.NetAdmin {
+		t.Error("expected the command to require NET_ADMIN")
+	}
+	wantOperation := &daemon.Operation{
+		Name:      daemon.OperationNetemStart,
+		Interface: "eth1",
+		Netem:     []string{"delay", "20000us", "loss", "2%"},
+	}
+	if !reflect.DeepEqual(cmd.Operation, wantOperation) {
+		t.Errorf("got operation %+v, want %+v", cmd.Operation, wantOperation)
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{value: "0", want: 0},
+		{value: "12.5", want: 12.5},
+		{value: "100%", want: 100},
+		{value: "100.1", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "NaN", wantErr: true},
+		{value: "%", wantErr: true},
+		{value: "ten", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePercent(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+)
+
+// runExec runs the command of the probe in its pod and checks that it exits with status 0
+func (p *Prober) runExec(ctx context.Context, namespace string, probe *v1alpha1.ExecProbe) (string, error) {
+	if probe.Namespace != "" {
+		namespace = probe.Namespace
+	}
+
+	result, err := p.executor.Exec(ctx, executor.Request{
+		Namespace: namespace,
+		Pod:       probe.Pod,
+		Container: probe.Container,
+		Command:   probe.Command,
+	})
+	if err != nil {
+		return "", fmt.Errorf("command failed in pod %s/%s: %v, stderr: %s", namespace, probe.Pod, err, truncate(strings.TrimSpace(result.Stderr)))
+	}
+	return fmt.Sprintf("command succeeded in pod %s/%s: %s", namespace, probe.Pod, truncate(strings.TrimSpace(result.Stdout))), nil
+}
+
+// maxOutputSize limits how much command output is kept as evidence
+const maxOutputSize = 256
+
+func truncate(output string) string {
+	if len(output) <= maxOutputSize {
+		return output
+	}
+	return output[:maxOutputSize] + "..."
+}
@@ -0,0 +1,78 @@
+package probe
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+)
+
+func TestRunExec(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		result    executor.Result
+		err       error
+		// wantNamespace is where the command runs
+		wantNamespace string
+		wantEvidence  string
+		wantErr       string
+	}{
+		{
+			name:          "succeeds in the experiment namespace",
+			result:        executor.Result{Stdout: "PONG\n"},
+			wantNamespace: "shop",
+			wantEvidence:  "command succeeded in pod shop/redis-0: PONG",
+		},
+		{
+			name:          "succeeds in the probe namespace",
+			namespace:     "cache",
+			result:        executor.Result{Stdout: "PONG\n"},
+			wantNamespace: "cache",
+			wantEvidence:  "command succeeded in pod cache/redis-0: PONG",
+		},
+		{
+			name:          "fails with a non-zero exit code",
+			result:        executor.Result{Stderr: "Could not connect to Redis\n"},
+			err:           &executor.ExitError{ExitCode: 1},
+			wantNamespace: "shop",
+			wantErr:       "command failed in pod shop/redis-0: command terminated with exit code 1, stderr: Could not connect to Redis",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := executor.NewFakeExecutor(func(req executor.Request) (executor.Result, error) {
+				return tt.result, tt.err
+			})
+			p := &Prober{executor: fake}
+
+			command := []string{"redis-cli", "ping"}
+			evidence, err := p.runExec(context.Background(), "shop", &v1alpha1.ExecProbe{
+				Namespace: tt.namespace,
+				Pod:       "redis-0",
+				Container: "redis",
+				Command:   command,
+			})
+
+			want := []executor.Request{{Namespace: tt.wantNamespace, Pod: "redis-0", Container: "redis", Command: command}}
+			if got := fake.Requests(); !reflect.DeepEqual(got, want) {
+				t.Errorf("got requests %v, want %v", got, want)
+			}
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("probe failed: %v", err)
+			}
+			if !strings.Contains(evidence, tt.wantEvidence) {
+				t.Errorf("got evidence %q, want it to contain %q", evidence, tt.wantEvidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+)
+
+// maxBodySize limits how much of a response body is read to match against
+const maxBodySize = 1 << 20
+
+// runHTTP requests the URL of the probe and checks the status and body of the response
+func (p *Prober) runHTTP(ctx context.Context, probe *v1alpha1.HTTPProbe) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid request: %v", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	expectedStatus := http.StatusOK
+	if probe.ExpectedStatus != 0 {
+		expectedStatus = int(probe.ExpectedStatus)
+	}
+	if resp.StatusCode != expectedStatus {
+		return "", fmt.Errorf("GET %s returned status %d, expected %d", probe.URL, resp.StatusCode, expectedStatus)
+	}
+
+	if probe.ExpectedBody != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		if err != nil {
+			return "", fmt.Errorf("failed to read response: %v", err)
+		}
+		if !strings.Contains(string(body), probe.ExpectedBody) {
+			return "", fmt.Errorf("GET %s returned a body without %q", probe.URL, probe.ExpectedBody)
+		}
+	}
+
+	return fmt.Sprintf("GET %s returned status %d", probe.URL, resp.StatusCode), nil
+}
@@ -0,0 +1,55 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runPodReadiness checks that enough of the pods matching the selector are ready
+func (p *Prober) runPodReadiness(ctx context.Context, namespace string, probe *v1alpha1.PodReadinessProbe) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&probe.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector: %v", err)
+	}
+	if probe.Namespace != "" {
+		namespace = probe.Namespace
+	}
+
+	pods, err := p.kubeclientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	ready := 0
+	for i := range pods.Items {
+		if podReady(&pods.Items[i]) {
+			ready++
+		}
+	}
+
+	minReady := 1
+	if probe.MinReady != 0 {
+		minReady = int(probe.MinReady)
+	}
+	if ready < minReady {
+		return "", fmt.Errorf("%d of %d pods matching %s are ready, expected at least %d", ready, len(pods.Items), selector, minReady)
+	}
+	return fmt.Sprintf("%d of %d pods matching %s are ready", ready, len(pods.Items), selector), nil
+}
+
+// podReady reports whether a pod that is not being deleted is ready
+func podReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
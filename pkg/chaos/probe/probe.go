@@ -0,0 +1,78 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	"github.com/chaos-engineering/controller/pkg/chaos/metrics"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// DefaultTimeout bounds a single evaluation of a probe that does not set a timeout
+const DefaultTimeout = 5 * time.Second
+
+// Prober evaluates the steady state probes of experiments
+type Prober struct {
+	kubeclientset kubernetes.Interface
+	dynamicclient dynamic.Interface
+
+	// mapper resolves the kinds of resource condition probes into resources
+	mapper     *restmapper.DeferredDiscoveryRESTMapper
+	httpClient *http.Client
+	// executor runs the commands of exec probes
+	executor executor.PodExecutor
+	// metricsBackend returns the backend that evaluates the queries of
+	// Prometheus probes against the API at the given address
+	metricsBackend func(address string) metrics.Backend
+}
+
+// NewProber creates a prober
+func NewProber(kubeclientset kubernetes.Interface, dynamicclient dynamic.Interface, restConfig *rest.Config) *Prober {
+	httpClient := &http.Client{}
+	return &Prober{
+		kubeclientset: kubeclientset,
+		dynamicclient: dynamicclient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeclientset.Discovery())),
+		httpClient:    httpClient,
+		executor:      executor.NewRemoteExecutor(kubeclientset, restConfig),
+		metricsBackend: func(address string) metrics.Backend {
+			return metrics.NewPrometheusBackend(address, httpClient)
+		},
+	}
+}
+
+// Run evaluates a probe of an experiment in the given namespace, returning
+// the evidence of the evaluation and an error if the probe did not pass
+func (p *Prober) Run(ctx context.Context, namespace string, probe *v1alpha1.Probe) (string, error) {
+	timeout := DefaultTimeout
+	if probe.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(probe.Timeout); err != nil {
+			return "", fmt.Errorf("invalid timeout: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch {
+	case probe.HTTP != nil:
+		return p.runHTTP(ctx, probe.HTTP)
+	case probe.ResourceCondition != nil:
+		return p.runResourceCondition(ctx, namespace, probe.ResourceCondition)
+	case probe.PodReadiness != nil:
+		return p.runPodReadiness(ctx, namespace, probe.PodReadiness)
+	case probe.Exec != nil:
+		return p.runExec(ctx, namespace, probe.Exec)
+	case probe.Prometheus != nil:
+		return p.runPrometheus(ctx, probe.Prometheus)
+	}
+	return "", fmt.Errorf("probe %s has no check", probe.Name)
+}
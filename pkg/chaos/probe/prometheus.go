@@ -0,0 +1,85 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/metrics"
+)
+
+// runPrometheus evaluates the query of the probe and checks that every sample of the
+// result compares to the threshold. An empty result does not pass.
+func (p *Prober) runPrometheus(ctx context.Context, probe *v1alpha1.PrometheusProbe) (string, error) {
+	threshold, err := strconv.ParseFloat(probe.Threshold, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid threshold: %v", err)
+	}
+
+	samples, err := p.metricsBackend(probe.Address).Query(ctx, probe.Query)
+	if err != nil {
+		return "", fmt.Errorf("query %q failed: %v", probe.Query, err)
+	}
+	if len(samples) == 0 {
+		return "", fmt.Errorf("query %q returned no samples", probe.Query)
+	}
+
+	var values, failures []string
+	for _, sample := range samples {
+		ok, err := compare(sample.Value, probe.Comparator, threshold)
+		if err != nil {
+			return "", err
+		}
+		value := formatSample(sample)
+		values = append(values, value)
+		if !ok {
+			failures = append(failures, value)
+		}
+	}
+
+	if len(failures) > 0 {
+		return "", fmt.Errorf("query %q returned %s, expected %s %s", probe.Query, strings.Join(failures, ", "), probe.Comparator, probe.Threshold)
+	}
+	return fmt.Sprintf("query %q returned %s", probe.Query, strings.Join(values, ", ")), nil
+}
+
+// compare reports whether "value comparator threshold" holds
+func compare(value float64, comparator string, threshold float64) (bool, error) {
+	switch comparator {
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	case "==":
+		return value == threshold, nil
+	case "!=":
+		return value != threshold, nil
+	}
+	return false, fmt.Errorf("unsupported comparator %q", comparator)
+}
+
+// formatSample formats a sample as {label="value",...}: value, or just the value for a scalar
+func formatSample(sample metrics.Sample) string {
+	value := strconv.FormatFloat(sample.Value, 'g', -1, 64)
+	if len(sample.Labels) == 0 {
+		return value
+	}
+
+	names := make([]string, 0, len(sample.Labels))
+	for name := range sample.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	labels := make([]string, 0, len(names))
+	for _, name := range names {
+		labels = append(labels, fmt.Sprintf("%s=%q", name, sample.Labels[name]))
+	}
+	return fmt.Sprintf("{%s}: %s", strings.Join(labels, ","), value)
+}
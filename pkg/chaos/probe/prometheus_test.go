@@ -0,0 +1,116 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/metrics"
+)
+
+const errorRateQuery = `sum(rate(http_requests_total{code=~"5.."}[1m])) by (pod)`
+
+func vectorResponse(samples string) string {
+	return `{"status":"success","data":{"resultType":"vector","result":[` + samples + `]}}`
+}
+
+func TestRunPrometheus(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		comparator string
+		threshold  string
+		// wantEvidence or wantErr are contained in the evidence or the error
+		wantEvidence string
+		wantErr      string
+	}{
+		{
+			name:         "threshold passes",
+			status:       http.StatusOK,
+			body:         vectorResponse(`{"metric":{"pod":"checkout-0"},"value":[1700000000,"0.01"]},{"metric":{"pod":"checkout-1"},"value":[1700000000,"0"]}`),
+			comparator:   "<",
+			threshold:    "0.05",
+			wantEvidence: `{pod="checkout-0"}: 0.01, {pod="checkout-1"}: 0`,
+		},
+		{
+			name:       "threshold fails",
+			status:     http.StatusOK,
+			body:       vectorResponse(`{"metric":{"pod":"checkout-0"},"value":[1700000000,"0.01"]},{"metric":{"pod":"checkout-1"},"value":[1700000000,"0.2"]}`),
+			comparator: "<",
+			threshold:  "0.05",
+			wantErr:    `returned {pod="checkout-1"}: 0.2, expected < 0.05`,
+		},
+		{
+			name:       "empty vector",
+			status:     http.StatusOK,
+			body:       vectorResponse(""),
+			comparator: "<",
+			threshold:  "0.05",
+			wantErr:    "returned no samples",
+		},
+		{
+			name:       "query error",
+			status:     http.StatusBadRequest,
+			body:       `{"status":"error","errorType":"bad_data","error":"invalid parameter \"query\""}`,
+			comparator: "<",
+			threshold:  "0.05",
+			wantErr:    "bad_data: invalid parameter",
+		},
+		{
+			name:       "unavailable",
+			status:     http.StatusServiceUnavailable,
+			body:       "Service Unavailable",
+			comparator: "<",
+			threshold:  "0.05",
+			wantErr:    "invalid response with status 503",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query" || r.URL.Query().Get("query") != errorRateQuery {
+					http.Error(w, fmt.Sprintf("unexpected request %s", r.URL), http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			const address = "http://prometheus.monitoring:9090"
+			p := &Prober{
+				metricsBackend: func(a string) metrics.Backend {
+					if a != address {
+						t.Errorf("got address %q, want %q", a, address)
+					}
+					return metrics.NewPrometheusBackend(server.URL, server.Client())
+				},
+			}
+
+			evidence, err := p.runPrometheus(context.Background(), &v1alpha1.PrometheusProbe{
+				Address:    address,
+				Query:      errorRateQuery,
+				Comparator: tt.comparator,
+				Threshold:  tt.threshold,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("probe failed: %v", err)
+			}
+			if !strings.Contains(evidence, tt.wantEvidence) {
+				t.Errorf("got evidence %q, want it to contain %q", evidence, tt.wantEvidence)
+			}
+		})
+	}
+}
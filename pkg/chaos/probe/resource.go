@@ -0,0 +1,68 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// runResourceCondition checks that a resource has a status condition with the expected status
+func (p *Prober) runResourceCondition(ctx context.Context, namespace string, probe *v1alpha1.ResourceConditionProbe) (string, error) {
+	gv, err := schema.ParseGroupVersion(probe.APIVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid apiVersion: %v", err)
+	}
+	gk := schema.GroupKind{Group: gv.Group, Kind: probe.Kind}
+
+	mapping, err := p.mapper.RESTMapping(gk, gv.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind may belong to a CRD installed after discovery was cached
+		p.mapper.Reset()
+		mapping, err = p.mapper.RESTMapping(gk, gv.Version)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %v", probe.Kind, err)
+	}
+
+	if probe.Namespace != "" {
+		namespace = probe.Namespace
+	}
+	resource := p.dynamicclient.Resource(mapping.Resource)
+	var obj *unstructured.Unstructured
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		obj, err = resource.Namespace(namespace).Get(ctx, probe.Name, metav1.GetOptions{})
+	} else {
+		obj, err = resource.Get(ctx, probe.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s %s: %v", probe.Kind, probe.Name, err)
+	}
+
+	expected := probe.ConditionStatus
+	if expected == "" {
+		expected = string(metav1.ConditionTrue)
+	}
+
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return "", fmt.Errorf("%s %s has malformed conditions: %v", probe.Kind, probe.Name, err)
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != probe.ConditionType {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		if status != expected {
+			message, _ := condition["message"].(string)
+			return "", fmt.Errorf("%s %s has condition %s=%s, expected %s: %s", probe.Kind, probe.Name, probe.ConditionType, status, expected, message)
+		}
+		return fmt.Sprintf("%s %s has condition %s=%s", probe.Kind, probe.Name, probe.ConditionType, status), nil
+	}
+	return "", fmt.Errorf("%s %s has no condition %s", probe.Kind, probe.Name, probe.ConditionType)
+}
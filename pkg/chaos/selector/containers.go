@@ -0,0 +1,29 @@
+package selector
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CheckContainers checks that every pod has a container with each of the names
+func CheckContainers(pods []corev1.Pod, names []string) error {
+	for i := range pods {
+		pod := &pods[i]
+		for _, name := range names {
+			if !hasContainer(pod, name) {
+				return fmt.Errorf("pod %s/%s has no container %s", pod.Namespace, pod.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+func hasContainer(pod *corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package selector
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FilterByMode picks the pods to inject out of the candidates according to the
+// mode. The same candidates, mode, value and seed always yield the same pods.
+func FilterByMode(pods []corev1.Pod, mode, value string, seed int64) ([]corev1.Pod, error) {
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no pods to select from")
+	}
+
+	// Sort before shuffling so the selection does not depend on list order
+	candidates := make([]corev1.Pod, len(pods))
+	copy(candidates, pods)
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Namespace != candidates[j].Namespace {
+			return candidates[i].Namespace < candidates[j].Namespace
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	random := rand.New(rand.NewSource(seed))
+	random.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	count, err := podCount(len(candidates), mode, value, random)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[:count], nil
+}
+
+// ValidateMode checks that the value is valid for the mode
+func ValidateMode(mode, value string) error {
+	_, err := podCount(1, mode, value, rand.New(rand.NewSource(0)))
+	return err
+}
+
+// podCount returns how many of total pods the mode selects
+func podCount(total int, mode, value string, random *rand.Rand) (int, error) {
+	switch mode {
+	case "", v1alpha1.ModeAll:
+		return total, nil
+	case v1alpha1.ModeOne:
+		return 1, nil
+	case v1alpha1.ModeFixed:
+		count, err := strconv.Atoi(value)
+		if err != nil || count <= 0 {
+			return 0, fmt.Errorf("mode %s requires a positive pod count, got %q", mode, value)
+		}
+		if count > total {
+			count = total
+		}
+		return count, nil
+	case v1alpha1.ModeFixedPercent, v1alpha1.ModeRandomMaxPercent:
+		percent, err := strconv.Atoi(value)
+		if err != nil || percent <= 0 || percent > 100 {
+			return 0, fmt.Errorf("mode %s requires a percentage between 1 and 100, got %q", mode, value)
+		}
+		if mode == v1alpha1.ModeRandomMaxPercent {
+			percent = random.Intn(percent) + 1
+		}
+		count := total * percent / 100
+		// Always inject at least one pod
+		if count == 0 {
+			count = 1
+		}
+		return count, nil
+	default:
+		return 0, fmt.Errorf("unknown mode: %s", mode)
+	}
+}
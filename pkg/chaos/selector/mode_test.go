@@ -0,0 +1,138 @@
+package selector
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// candidatePods returns n running pods spread over two namespaces
+func candidatePods(n int) []corev1.Pod {
+	pods := make([]corev1.Pod, 0, n)
+	for i := 0; i < n; i++ {
+		pods = append(pods, *runningPod(fmt.Sprintf("ns-%d", i%2), fmt.Sprintf("pod-%d", i), nil))
+	}
+	return pods
+}
+
+func TestPodCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int
+		mode    string
+		value   string
+		want    int
+		wantErr string
+	}{
+		{name: "default mode selects all", total: 7, want: 7},
+		{name: "all", total: 7, mode: v1alpha1.ModeAll, want: 7},
+		{name: "one", total: 7, mode: v1alpha1.ModeOne, want: 1},
+		{name: "fixed", total: 7, mode: v1alpha1.ModeFixed, value: "3", want: 3},
+		{name: "fixed capped at total", total: 7, mode: v1alpha1.ModeFixed, value: "10", want: 7},
+		{name: "fixed zero", total: 7, mode: v1alpha1.ModeFixed, value: "0", wantErr: "requires a positive pod count"},
+		{name: "fixed not a number", total: 7, mode: v1alpha1.ModeFixed, value: "two", wantErr: "requires a positive pod count"},
+		{name: "fixed-percent rounds down", total: 7, mode: v1alpha1.ModeFixedPercent, value: "50", want: 3},
+		{name: "fixed-percent at least one", total: 7, mode: v1alpha1.ModeFixedPercent, value: "1", want: 1},
+		{name: "fixed-percent of 100", total: 7, mode: v1alpha1.ModeFixedPercent, value: "100", want: 7},
+		{name: "fixed-percent above 100", total: 7, mode: v1alpha1.ModeFixedPercent, value: "101", wantErr: "between 1 and 100"},
+		{name: "fixed-percent zero", total: 7, mode: v1alpha1.ModeFixedPercent, value: "0", wantErr: "between 1 and 100"},
+		{name: "random-max-percent above 100", total: 7, mode: v1alpha1.ModeRandomMaxPercent, value: "150", wantErr: "between 1 and 100"},
+		{name: "random-max-percent empty", total: 7, mode: v1alpha1.ModeRandomMaxPercent, wantErr: "between 1 and 100"},
+		{name: "unknown mode", total: 7, mode: "most", wantErr: "unknown mode: most"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podCount(tt.total, tt.mode, tt.value, rand.New(rand.NewSource(1)))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("podCount failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d pods, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodCountRandomMaxPercentBounds(t *testing.T) {
+	// At most 40% of 10 pods, and never none
+	for seed := int64(0); seed < 100; seed++ {
+		count, err := podCount(10, v1alpha1.ModeRandomMaxPercent, "40", rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("podCount failed: %v", err)
+		}
+		if count < 1 || count > 4 {
+			t.Fatalf("seed %d: got %d pods, want between 1 and 4", seed, count)
+		}
+	}
+}
+
+func TestFilterByModeIsReproducible(t *testing.T) {
+	pods := candidatePods(10)
+	shuffled := candidatePods(10)
+	rand.New(rand.NewSource(42)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	modes := []struct{ mode, value string }{
+		{v1alpha1.ModeOne, ""},
+		{v1alpha1.ModeFixed, "3"},
+		{v1alpha1.ModeFixedPercent, "50"},
+		{v1alpha1.ModeRandomMaxPercent, "80"},
+	}
+	for _, m := range modes {
+		t.Run(m.mode, func(t *testing.T) {
+			want, err := FilterByMode(pods, m.mode, m.value, 7)
+			if err != nil {
+				t.Fatalf("FilterByMode failed: %v", err)
+			}
+			got, err := FilterByMode(shuffled, m.mode, m.value, 7)
+			if err != nil {
+				t.Fatalf("FilterByMode failed: %v", err)
+			}
+			if !reflect.DeepEqual(podNames(got), podNames(want)) {
+				t.Errorf("shuffled input selected %v, want %v", podNames(got), podNames(want))
+			}
+		})
+	}
+}
+
+func TestFilterByModeSeedVariesSelection(t *testing.T) {
+	pods := candidatePods(10)
+	seen := map[string]bool{}
+	for seed := int64(0); seed < 20; seed++ {
+		selected, err := FilterByMode(pods, v1alpha1.ModeOne, "", seed)
+		if err != nil {
+			t.Fatalf("FilterByMode failed: %v", err)
+		}
+		seen[podNames(selected)[0]] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("20 seeds always selected %v", seen)
+	}
+}
+
+func TestFilterByModeWithoutPods(t *testing.T) {
+	if _, err := FilterByMode(nil, v1alpha1.ModeAll, "", 1); err == nil {
+		t.Error("expected an error without candidate pods")
+	}
+}
+
+func TestValidateMode(t *testing.T) {
+	if err := ValidateMode(v1alpha1.ModeRandomMaxPercent, "100"); err != nil {
+		t.Errorf("ValidateMode failed: %v", err)
+	}
+	if err := ValidateMode(v1alpha1.ModeFixed, "-1"); err == nil {
+		t.Error("expected an error for a negative pod count")
+	}
+}
@@ -0,0 +1,162 @@
+package selector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodSelector resolves the target of a chaos experiment into concrete pods
+type PodSelector struct {
+	client kubernetes.Interface
+}
+
+// NewPodSelector creates a new pod selector
+func NewPodSelector(client kubernetes.Interface) *PodSelector {
+	return &PodSelector{
+		client: client,
+	}
+}
+
+// Select returns the pods targeted by the experiment. Pod targets are resolved
+// by name or label selector, workload and service targets through the pod
+// selector of the named object.
+func (s *PodSelector) Select(ctx context.Context, experiment *v1alpha1.ChaosExperiment) ([]corev1.Pod, error) {
+	target := experiment.Spec.Target
+
+	namespaces, err := s.selectNamespaces(ctx, experiment)
+	if err != nil {
+		return nil, err
+	}
+
+	var targetSelector labels.Selector
+	if target.Selector != nil {
+		targetSelector, err = metav1.LabelSelectorAsSelector(target.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target selector: %v", err)
+		}
+	}
+
+	var pods []corev1.Pod
+	switch target.Kind {
+	case "", KindPod:
+		// Without a label selector the target is the pod of that name in each namespace
+		if targetSelector == nil {
+			if target.Name == "" {
+				return nil, fmt.Errorf("target must set either name or selector")
+			}
+			for _, namespace := range namespaces {
+				pod, err := s.client.CoreV1().Pods(namespace).Get(ctx, target.Name, metav1.GetOptions{})
+				if err != nil {
+					// With a namespace selector the pod only has to exist in some of the namespaces
+					if errors.IsNotFound(err) && target.NamespaceSelector != nil {
+						continue
+					}
+					return nil, fmt.Errorf("failed to get target pod %s/%s: %v", namespace, target.Name, err)
+				}
+				pods = append(pods, *pod)
+			}
+			break
+		}
+
+		for _, namespace := range namespaces {
+			nsPods, err := s.listPods(ctx, namespace, targetSelector)
+			if err != nil {
+				return nil, err
+			}
+			pods = append(pods, nsPods...)
+		}
+	default:
+		if target.Name == "" {
+			return nil, fmt.Errorf("target of kind %s must set name", target.Kind)
+		}
+
+		for _, namespace := range namespaces {
+			podSelector, err := s.workloadSelector(ctx, target.Kind, namespace, target.Name)
+			if err != nil {
+				// With a namespace selector the workload only has to exist in some of the namespaces
+				if errors.IsNotFound(err) && target.NamespaceSelector != nil {
+					continue
+				}
+				return nil, fmt.Errorf("failed to resolve %s %s/%s: %v", target.Kind, namespace, target.Name, err)
+			}
+
+			// A target selector narrows the workload's pods further
+			if targetSelector != nil {
+				requirements, _ := targetSelector.Requirements()
+				podSelector = podSelector.Add(requirements...)
+			}
+
+			nsPods, err := s.listPods(ctx, namespace, podSelector)
+			if err != nil {
+				return nil, err
+			}
+			pods = append(pods, nsPods...)
+		}
+	}
+
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no running pods found for target")
+	}
+
+	return pods, nil
+}
+
+// listPods returns the pods in a namespace that match the selector and can be injected
+func (s *PodSelector) listPods(ctx context.Context, namespace string, selector labels.Selector) ([]corev1.Pod, error) {
+	list, err := s.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %v", namespace, err)
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range list.Items {
+		if isSelectable(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// selectNamespaces returns the namespaces to search for target pods
+func (s *PodSelector) selectNamespaces(ctx context.Context, experiment *v1alpha1.ChaosExperiment) ([]string, error) {
+	target := experiment.Spec.Target
+
+	if target.NamespaceSelector == nil {
+		namespace := target.Namespace
+		if namespace == "" {
+			namespace = experiment.Namespace
+		}
+		return []string{namespace}, nil
+	}
+
+	nsSelector, err := metav1.LabelSelectorAsSelector(target.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector: %v", err)
+	}
+
+	list, err := s.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: nsSelector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %v", err)
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no namespaces match selector %q", nsSelector.String())
+	}
+
+	namespaces := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		namespaces = append(namespaces, ns.Name)
+	}
+	return namespaces, nil
+}
+
+// isSelectable reports whether a pod can have chaos injected into it
+func isSelectable(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning
+}
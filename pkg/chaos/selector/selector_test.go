@@ -0,0 +1,154 @@
+package selector
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func runningPod(namespace, name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+// podNames returns the namespace/name of the pods, sorted
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectTargets selects the pods of an experiment in the default namespace with the target
+func selectTargets(t *testing.T, target v1alpha1.TargetResource, objects ...runtime.Object) ([]string, error) {
+	t.Helper()
+	experiment := &v1alpha1.ChaosExperiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "experiment", Namespace: "default"},
+		Spec:       v1alpha1.ChaosExperimentSpec{Target: target},
+	}
+	pods, err := NewPodSelector(fake.NewSimpleClientset(objects...)).Select(context.Background(), experiment)
+	return podNames(pods), err
+}
+
+func TestSelectPods(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	team := map[string]string{"team": "checkout"}
+	terminating := runningPod("shop", "web-terminating", web)
+	terminating.DeletionTimestamp = &metav1.Time{}
+	pending := runningPod("shop", "web-pending", web)
+	pending.Status.Phase = corev1.PodPending
+
+	objects := []runtime.Object{
+		namespace("default", nil),
+		namespace("shop", team),
+		namespace("cart", team),
+		runningPod("default", "web-0", web),
+		runningPod("default", "db-0", map[string]string{"app": "db"}),
+		runningPod("shop", "web-0", web),
+		runningPod("cart", "web-1", web),
+		terminating,
+		pending,
+	}
+
+	tests := []struct {
+		name    string
+		target  v1alpha1.TargetResource
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "name in the experiment namespace",
+			target: v1alpha1.TargetResource{Kind: KindPod, Name: "db-0"},
+			want:   []string{"default/db-0"},
+		},
+		{
+			name:   "name in the target namespace",
+			target: v1alpha1.TargetResource{Kind: KindPod, Name: "web-0", Namespace: "shop"},
+			want:   []string{"shop/web-0"},
+		},
+		{
+			name:   "label selector skips pods that are not running",
+			target: v1alpha1.TargetResource{Kind: KindPod, Namespace: "shop", Selector: &metav1.LabelSelector{MatchLabels: web}},
+			want:   []string{"shop/web-0"},
+		},
+		{
+			name: "label selector across selected namespaces",
+			target: v1alpha1.TargetResource{
+				Kind:              KindPod,
+				Selector:          &metav1.LabelSelector{MatchLabels: web},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: team},
+			},
+			want: []string{"cart/web-1", "shop/web-0"},
+		},
+		{
+			name: "name in every selected namespace that has it",
+			target: v1alpha1.TargetResource{
+				Kind:              KindPod,
+				Name:              "web-0",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: team},
+			},
+			want: []string{"shop/web-0"},
+		},
+		{
+			name:    "name that does not exist",
+			target:  v1alpha1.TargetResource{Kind: KindPod, Name: "web-9"},
+			wantErr: "failed to get target pod default/web-9",
+		},
+		{
+			name: "name in none of the selected namespaces",
+			target: v1alpha1.TargetResource{
+				Kind:              KindPod,
+				Name:              "db-0",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: team},
+			},
+			wantErr: "no running pods found for target",
+		},
+		{
+			name: "namespace selector without matches",
+			target: v1alpha1.TargetResource{
+				Kind:              KindPod,
+				Selector:          &metav1.LabelSelector{MatchLabels: web},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}},
+			},
+			wantErr: "no namespaces match selector",
+		},
+		{
+			name:    "neither name nor selector",
+			target:  v1alpha1.TargetResource{Kind: KindPod},
+			wantErr: "target must set either name or selector",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectTargets(t, tt.target, objects...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got pods %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
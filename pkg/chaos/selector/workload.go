@@ -0,0 +1,71 @@
+package selector
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Supported target kinds
+const (
+	KindPod         = "Pod"
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+	KindReplicaSet  = "ReplicaSet"
+	KindService     = "Service"
+)
+
+// Kinds lists the supported target kinds
+var Kinds = []string{KindPod, KindDeployment, KindStatefulSet, KindDaemonSet, KindReplicaSet, KindService}
+
+// workloadSelector returns the pod selector of the named workload or service
+func (s *PodSelector) workloadSelector(ctx context.Context, kind, namespace, name string) (labels.Selector, error) {
+	var selector *metav1.LabelSelector
+
+	switch kind {
+	case KindDeployment:
+		deployment, err := s.client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = deployment.Spec.Selector
+	case KindStatefulSet:
+		statefulSet, err := s.client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = statefulSet.Spec.Selector
+	case KindDaemonSet:
+		daemonSet, err := s.client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = daemonSet.Spec.Selector
+	case KindReplicaSet:
+		replicaSet, err := s.client.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = replicaSet.Spec.Selector
+	case KindService:
+		service, err := s.client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		// A service without a selector has its endpoints managed externally
+		if len(service.Spec.Selector) == 0 {
+			return nil, fmt.Errorf("service %s/%s has no pod selector", namespace, name)
+		}
+		return labels.SelectorFromSet(service.Spec.Selector), nil
+	default:
+		return nil, fmt.Errorf("unsupported target kind: %s", kind)
+	}
+
+	if selector == nil {
+		return nil, fmt.Errorf("%s %s/%s has no pod selector", kind, namespace, name)
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
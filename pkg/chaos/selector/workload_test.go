@@ -0,0 +1,122 @@
+package selector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSelectWorkloadPods(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	db := map[string]string{"app": "db"}
+	agent := map[string]string{"app": "agent"}
+	canary := map[string]string{"app": "web", "track": "canary"}
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+
+	objects := []runtime.Object{
+		namespace("default", nil),
+		&appsv1.Deployment{ObjectMeta: meta("web"), Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: web}}},
+		&appsv1.Deployment{ObjectMeta: meta("orphan")},
+		&appsv1.StatefulSet{ObjectMeta: meta("db"), Spec: appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: db}}},
+		&appsv1.DaemonSet{ObjectMeta: meta("agent"), Spec: appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: agent}}},
+		&appsv1.ReplicaSet{ObjectMeta: meta("web-5d4f"), Spec: appsv1.ReplicaSetSpec{Selector: &metav1.LabelSelector{MatchLabels: web}}},
+		&corev1.Service{ObjectMeta: meta("web"), Spec: corev1.ServiceSpec{Selector: web}},
+		&corev1.Service{ObjectMeta: meta("external")},
+		runningPod("default", "web-1", web),
+		runningPod("default", "web-2", canary),
+		runningPod("default", "db-0", db),
+		runningPod("default", "agent-x", agent),
+	}
+
+	tests := []struct {
+		name    string
+		target  v1alpha1.TargetResource
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "deployment",
+			target: v1alpha1.TargetResource{Kind: KindDeployment, Name: "web"},
+			want:   []string{"default/web-1", "default/web-2"},
+		},
+		{
+			name:   "statefulset",
+			target: v1alpha1.TargetResource{Kind: KindStatefulSet, Name: "db"},
+			want:   []string{"default/db-0"},
+		},
+		{
+			name:   "daemonset",
+			target: v1alpha1.TargetResource{Kind: KindDaemonSet, Name: "agent"},
+			want:   []string{"default/agent-x"},
+		},
+		{
+			name:   "replicaset",
+			target: v1alpha1.TargetResource{Kind: KindReplicaSet, Name: "web-5d4f"},
+			want:   []string{"default/web-1", "default/web-2"},
+		},
+		{
+			name:   "service",
+			target: v1alpha1.TargetResource{Kind: KindService, Name: "web"},
+			want:   []string{"default/web-1", "default/web-2"},
+		},
+		{
+			name: "selector narrows the workload pods",
+			target: v1alpha1.TargetResource{
+				Kind:     KindDeployment,
+				Name:     "web",
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"track": "canary"}},
+			},
+			want: []string{"default/web-2"},
+		},
+		{
+			name:    "service without a selector",
+			target:  v1alpha1.TargetResource{Kind: KindService, Name: "external"},
+			wantErr: "service default/external has no pod selector",
+		},
+		{
+			name:    "workload without a selector",
+			target:  v1alpha1.TargetResource{Kind: KindDeployment, Name: "orphan"},
+			wantErr: "Deployment default/orphan has no pod selector",
+		},
+		{
+			name:    "missing workload",
+			target:  v1alpha1.TargetResource{Kind: KindStatefulSet, Name: "cache"},
+			wantErr: "failed to resolve StatefulSet default/cache",
+		},
+		{
+			name:    "workload without a name",
+			target:  v1alpha1.TargetResource{Kind: KindDeployment},
+			wantErr: "target of kind Deployment must set name",
+		},
+		{
+			name:    "unsupported kind",
+			target:  v1alpha1.TargetResource{Kind: "CronJob", Name: "web"},
+			wantErr: "unsupported target kind: CronJob",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectTargets(t, tt.target, objects...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got pods %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
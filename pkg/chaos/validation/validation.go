@@ -0,0 +1,402 @@
+package validation
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/experiments"
+	"github.com/chaos-engineering/controller/pkg/chaos/selector"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// supportedInjectors are the injectors experiments can select
+var supportedInjectors = []string{v1alpha1.InjectorExec, v1alpha1.InjectorEphemeral, v1alpha1.InjectorDaemon}
+
+// ValidateChaosExperiment checks the spec of a chaos experiment, returning every problem found
+func ValidateChaosExperiment(experiment *v1alpha1.ChaosExperiment) field.ErrorList {
+	return ValidateChaosExperimentSpec(&experiment.Spec, field.NewPath("spec"))
+}
+
+// ValidateChaosExperimentSpec checks a chaos experiment spec
+func ValidateChaosExperimentSpec(spec *v1alpha1.ChaosExperimentSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateTarget(&spec.Target, fldPath.Child("target"))
+
+	typePath := fldPath.Child("experimentType")
+	if spec.ExperimentType == "" {
+		allErrs = append(allErrs, field.Required(typePath, ""))
+	} else if !contains(experiments.ExperimentTypes, spec.ExperimentType) {
+		allErrs = append(allErrs, field.NotSupported(typePath, spec.ExperimentType, experiments.ExperimentTypes))
+	} else if err := experiments.ValidateParameters(spec.ExperimentType, spec.Parameters); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("parameters"), spec.Parameters, err.Error()))
+	} else if !contains(experiments.ContainerExperimentTypes, spec.ExperimentType) {
+		if len(spec.Target.ContainerNames) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("target", "containerNames"),
+				"not supported by "+spec.ExperimentType+" experiments, which act on the whole pod"))
+		}
+		if spec.Injector != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("injector"),
+				"not supported by "+spec.ExperimentType+" experiments, which act on the whole pod"))
+		}
+	}
+
+	if spec.Injector != "" && !contains(supportedInjectors, spec.Injector) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("injector"), spec.Injector, supportedInjectors))
+	}
+
+	durationPath := fldPath.Child("duration")
+	if spec.Duration == "" {
+		allErrs = append(allErrs, field.Required(durationPath, ""))
+	} else if duration, err := time.ParseDuration(spec.Duration); err != nil {
+		allErrs = append(allErrs, field.Invalid(durationPath, spec.Duration, err.Error()))
+	} else if duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(durationPath, spec.Duration, "must be positive"))
+	}
+
+	if err := selector.ValidateMode(spec.Mode, spec.Value); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), spec.Value, err.Error()))
+	}
+
+	if spec.SteadyState != nil {
+		allErrs = append(allErrs, validateSteadyState(spec.SteadyState, fldPath.Child("steadyState"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateChaosSchedule checks the spec of a chaos schedule, including its experiment template
+func ValidateChaosSchedule(schedule *v1alpha1.ChaosSchedule) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := &schedule.Spec
+	var allErrs field.ErrorList
+
+	if spec.Schedule == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("schedule"), ""))
+	} else if _, err := cron.ParseStandard(spec.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), spec.Schedule, err.Error()))
+	}
+
+	switch spec.ConcurrencyPolicy {
+	case "", v1alpha1.ConcurrencyAllow, v1alpha1.ConcurrencyForbid, v1alpha1.ConcurrencyReplace:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("concurrencyPolicy"), spec.ConcurrencyPolicy,
+			[]string{v1alpha1.ConcurrencyAllow, v1alpha1.ConcurrencyForbid, v1alpha1.ConcurrencyReplace}))
+	}
+
+	if spec.StartingDeadlineSeconds != nil && *spec.StartingDeadlineSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("startingDeadlineSeconds"), *spec.StartingDeadlineSeconds, "must not be negative"))
+	}
+	if spec.SuccessfulExperimentsHistoryLimit != nil && *spec.SuccessfulExperimentsHistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("successfulExperimentsHistoryLimit"), *spec.SuccessfulExperimentsHistoryLimit, "must not be negative"))
+	}
+	if spec.FailedExperimentsHistoryLimit != nil && *spec.FailedExperimentsHistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("failedExperimentsHistoryLimit"), *spec.FailedExperimentsHistoryLimit, "must not be negative"))
+	}
+
+	return append(allErrs, ValidateChaosExperimentSpec(&spec.ExperimentTemplate, fldPath.Child("experimentTemplate"))...)
+}
+
+// ValidateChaosWorkflow checks the steps of a chaos workflow and that they form a graph without cycles
+func ValidateChaosWorkflow(workflow *v1alpha1.ChaosWorkflow) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	spec := &workflow.Spec
+	var allErrs field.ErrorList
+
+	// The experiments of the steps are labelled with the workflow name and named
+	// <workflow>-<step>. Limiting the workflow name to a label value also keeps
+	// those names within the length of an object name, as step names are labels.
+	if msgs := utilvalidation.IsValidLabelValue(workflow.Name); len(msgs) > 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), workflow.Name,
+			"must be a valid label value to label the experiments of the workflow: "+strings.Join(msgs, ", ")))
+	}
+
+	steps := make(map[string]*v1alpha1.WorkflowStep, len(spec.Steps))
+	stepTypes := []string{v1alpha1.StepTypeExperiment, v1alpha1.StepTypeSerial, v1alpha1.StepTypeParallel, v1alpha1.StepTypeSuspend}
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		stepPath := fldPath.Child("steps").Index(i)
+
+		if step.Name == "" {
+			allErrs = append(allErrs, field.Required(stepPath.Child("name"), ""))
+		} else if msgs := utilvalidation.IsDNS1123Label(step.Name); len(msgs) > 0 {
+			allErrs = append(allErrs, field.Invalid(stepPath.Child("name"), step.Name, strings.Join(msgs, ", ")))
+		} else if _, ok := steps[step.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(stepPath.Child("name"), step.Name))
+		} else {
+			steps[step.Name] = step
+		}
+
+		switch step.Type {
+		case v1alpha1.StepTypeExperiment:
+			switch {
+			case step.Experiment == nil && step.ExperimentRef == nil:
+				allErrs = append(allErrs, field.Required(stepPath, "either experiment or experimentRef must be set"))
+			case step.Experiment != nil && step.ExperimentRef != nil:
+				allErrs = append(allErrs, field.Forbidden(stepPath.Child("experimentRef"), "may not be set together with experiment"))
+			case step.Experiment != nil:
+				allErrs = append(allErrs, ValidateChaosExperimentSpec(step.Experiment, stepPath.Child("experiment"))...)
+			case step.ExperimentRef.Name == "":
+				allErrs = append(allErrs, field.Required(stepPath.Child("experimentRef", "name"), ""))
+			}
+		case v1alpha1.StepTypeSerial, v1alpha1.StepTypeParallel:
+			if len(step.Children) == 0 {
+				allErrs = append(allErrs, field.Required(stepPath.Child("children"), "must be set for "+step.Type+" steps"))
+			}
+		case v1alpha1.StepTypeSuspend:
+			durationPath := stepPath.Child("duration")
+			if step.Duration == "" {
+				allErrs = append(allErrs, field.Required(durationPath, "must be set for Suspend steps"))
+			} else if duration, err := time.ParseDuration(step.Duration); err != nil {
+				allErrs = append(allErrs, field.Invalid(durationPath, step.Duration, err.Error()))
+			} else if duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(durationPath, step.Duration, "must be positive"))
+			}
+		case "":
+			allErrs = append(allErrs, field.Required(stepPath.Child("type"), ""))
+		default:
+			allErrs = append(allErrs, field.NotSupported(stepPath.Child("type"), step.Type, stepTypes))
+		}
+	}
+
+	for i := range spec.Steps {
+		for j, child := range spec.Steps[i].Children {
+			if _, ok := steps[child]; !ok {
+				allErrs = append(allErrs, field.NotFound(fldPath.Child("steps").Index(i).Child("children").Index(j), child))
+			}
+		}
+	}
+
+	entryPath := fldPath.Child("entry")
+	if spec.Entry == "" {
+		allErrs = append(allErrs, field.Required(entryPath, ""))
+	} else if _, ok := steps[spec.Entry]; !ok {
+		allErrs = append(allErrs, field.NotFound(entryPath, spec.Entry))
+	} else if cycle := findCycle(steps, spec.Entry, map[string]bool{}, map[string]bool{}); cycle != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("steps"), cycle, "step runs itself"))
+	}
+
+	return allErrs
+}
+
+// findCycle returns the name of a step that is its own descendant, searching depth
+// first from the given step. Unknown children are reported elsewhere and skipped.
+func findCycle(steps map[string]*v1alpha1.WorkflowStep, name string, visiting, done map[string]bool) string {
+	if done[name] {
+		return ""
+	}
+	if visiting[name] {
+		return name
+	}
+	step, ok := steps[name]
+	if !ok {
+		return ""
+	}
+
+	visiting[name] = true
+	for _, child := range step.Children {
+		if cycle := findCycle(steps, child, visiting, done); cycle != "" {
+			return cycle
+		}
+	}
+	visiting[name] = false
+	done[name] = true
+	return ""
+}
+
+// validateSteadyState checks the probes of a steady state hypothesis
+func validateSteadyState(steadyState *v1alpha1.SteadyState, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if steadyState.Interval != "" {
+		allErrs = append(allErrs, validatePositiveDuration(steadyState.Interval, fldPath.Child("interval"))...)
+	}
+	if len(steadyState.Probes) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("probes"), ""))
+	}
+
+	names := map[string]bool{}
+	for i := range steadyState.Probes {
+		probe := &steadyState.Probes[i]
+		probePath := fldPath.Child("probes").Index(i)
+
+		if probe.Name == "" {
+			allErrs = append(allErrs, field.Required(probePath.Child("name"), ""))
+		} else if names[probe.Name] {
+			allErrs = append(allErrs, field.Duplicate(probePath.Child("name"), probe.Name))
+		}
+		names[probe.Name] = true
+
+		if probe.Timeout != "" {
+			allErrs = append(allErrs, validatePositiveDuration(probe.Timeout, probePath.Child("timeout"))...)
+		}
+		if probe.AbortThreshold < 0 {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("abortThreshold"), probe.AbortThreshold, "must not be negative"))
+		}
+
+		checks := 0
+		if probe.HTTP != nil {
+			checks++
+			allErrs = append(allErrs, validateHTTPProbe(probe.HTTP, probePath.Child("http"))...)
+		}
+		if probe.ResourceCondition != nil {
+			checks++
+			allErrs = append(allErrs, validateResourceConditionProbe(probe.ResourceCondition, probePath.Child("resourceCondition"))...)
+		}
+		if probe.PodReadiness != nil {
+			checks++
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&probe.PodReadiness.Selector,
+				metav1validation.LabelSelectorValidationOptions{}, probePath.Child("podReadiness", "selector"))...)
+			if probe.PodReadiness.MinReady < 0 {
+				allErrs = append(allErrs, field.Invalid(probePath.Child("podReadiness", "minReady"), probe.PodReadiness.MinReady, "must not be negative"))
+			}
+		}
+		if probe.Exec != nil {
+			checks++
+			if probe.Exec.Pod == "" {
+				allErrs = append(allErrs, field.Required(probePath.Child("exec", "pod"), ""))
+			}
+			if len(probe.Exec.Command) == 0 {
+				allErrs = append(allErrs, field.Required(probePath.Child("exec", "command"), ""))
+			}
+		}
+		if probe.Prometheus != nil {
+			checks++
+			allErrs = append(allErrs, validatePrometheusProbe(probe.Prometheus, probePath.Child("prometheus"))...)
+		}
+		if checks != 1 {
+			allErrs = append(allErrs, field.Invalid(probePath, probe.Name, "exactly one of http, resourceCondition, podReadiness, exec and prometheus must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateHTTPProbe(probe *v1alpha1.HTTPProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if probe.URL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(probe.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), probe.URL, "must be an absolute http or https URL"))
+	}
+	if probe.ExpectedStatus != 0 && (probe.ExpectedStatus < 100 || probe.ExpectedStatus > 599) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("expectedStatus"), probe.ExpectedStatus, "must be an HTTP status code"))
+	}
+	return allErrs
+}
+
+func validateResourceConditionProbe(probe *v1alpha1.ResourceConditionProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if probe.APIVersion == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("apiVersion"), ""))
+	} else if _, err := schema.ParseGroupVersion(probe.APIVersion); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiVersion"), probe.APIVersion, err.Error()))
+	}
+	if probe.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kind"), ""))
+	}
+	if probe.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+	if probe.ConditionType == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("conditionType"), ""))
+	}
+	switch metav1.ConditionStatus(probe.ConditionStatus) {
+	case "", metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("conditionStatus"), probe.ConditionStatus,
+			[]string{string(metav1.ConditionTrue), string(metav1.ConditionFalse), string(metav1.ConditionUnknown)}))
+	}
+	return allErrs
+}
+
+// supportedComparators are the comparators of a Prometheus probe
+var supportedComparators = []string{"<", "<=", ">", ">=", "==", "!="}
+
+func validatePrometheusProbe(probe *v1alpha1.PrometheusProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if probe.Address == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("address"), ""))
+	} else if u, err := url.Parse(probe.Address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("address"), probe.Address, "must be an absolute http or https URL"))
+	}
+	if probe.Query == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("query"), ""))
+	}
+	if !slices.Contains(supportedComparators, probe.Comparator) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("comparator"), probe.Comparator, supportedComparators))
+	}
+	if probe.Threshold == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("threshold"), ""))
+	} else if _, err := strconv.ParseFloat(probe.Threshold, 64); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("threshold"), probe.Threshold, "must be a number"))
+	}
+	return allErrs
+}
+
+// validatePositiveDuration checks that a value parses as a positive duration
+func validatePositiveDuration(value string, fldPath *field.Path) field.ErrorList {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	if duration <= 0 {
+		return field.ErrorList{field.Invalid(fldPath, value, "must be positive")}
+	}
+	return nil
+}
+
+// validateTarget checks that the target can be resolved into pods
+func validateTarget(target *v1alpha1.TargetResource, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch target.Kind {
+	case "", selector.KindPod:
+		if target.Name == "" && target.Selector == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), "either name or selector must be set"))
+		}
+	default:
+		if !contains(selector.Kinds, target.Kind) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("kind"), target.Kind, selector.Kinds))
+		} else if target.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), "must be set for targets of kind "+target.Kind))
+		}
+	}
+
+	labelSelectorOpts := metav1validation.LabelSelectorValidationOptions{}
+	if target.Selector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(target.Selector, labelSelectorOpts, fldPath.Child("selector"))...)
+	}
+	if target.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(target.NamespaceSelector, labelSelectorOpts, fldPath.Child("namespaceSelector"))...)
+	}
+
+	names := map[string]bool{}
+	for i, name := range target.ContainerNames {
+		namePath := fldPath.Child("containerNames").Index(i)
+		if msgs := utilvalidation.IsDNS1123Label(name); len(msgs) > 0 {
+			allErrs = append(allErrs, field.Invalid(namePath, name, strings.Join(msgs, ", ")))
+		} else if names[name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, name))
+		}
+		names[name] = true
+	}
+
+	return allErrs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/experiments"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// abortRequested reports whether the experiment has been annotated for abort
+func abortRequested(experiment *v1alpha1.ChaosExperiment) bool {
+	return experiment.Annotations[v1alpha1.AnnotationAbort] == "true"
+}
+
+// handleAbortedExperiment recovers every target of an experiment whose abort was
+// requested and moves it to the aborted phase
+func (c *Controller) handleAbortedExperiment(experiment *v1alpha1.ChaosExperiment) error {
+	experiment = experiment.DeepCopy()
+
+	abortedBy := experiment.Annotations[v1alpha1.AnnotationAbortedBy]
+	reason := experiment.Annotations[v1alpha1.AnnotationAbortReason]
+	return c.abortExperiment(experiment, abortedBy, reason, v1alpha1.ReasonAbortRequested)
+}
+
+// abortExperiment recovers every target of the experiment and moves it to the aborted
+// phase, recording who aborted it and why. It modifies the given experiment.
+func (c *Controller) abortExperiment(experiment *v1alpha1.ChaosExperiment, abortedBy, reason, conditionReason string) error {
+	klog.Infof("Aborting chaos experiment %s/%s (by %q: %q)", experiment.Namespace, experiment.Name, abortedBy, reason)
+
+	experimentImpl := experiments.ExperimentFactory(c.kubeclientset, c.injectors, experiment.Spec.ExperimentType)
+	if experimentImpl != nil {
+		if err := c.recoverTargets(experimentImpl, experiment); err != nil {
+			// Keep the current phase so that recovery is retried
+			experiment.Status.Message = fmt.Sprintf("Failed to recover targets while aborting: %v", err)
+			setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionFalse, v1alpha1.ReasonRecoveryFailed, experiment.Status.Message)
+			if _, updateErr := c.updateStatus(experiment); updateErr != nil {
+				klog.Errorf("Failed to update experiment status: %v", updateErr)
+			}
+			return fmt.Errorf("failed to recover targets: %v", err)
+		}
+	}
+
+	message := "Experiment aborted"
+	if abortedBy != "" {
+		message = fmt.Sprintf("%s by %s", message, abortedBy)
+	}
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+
+	experiment.Status.Phase = v1alpha1.PhaseAborted
+	now := metav1.NewTime(c.clock.Now())
+	experiment.Status.EndTime = &now
+	experiment.Status.PausedAt = nil
+	experiment.Status.Message = message
+	experiment.Status.AbortedBy = abortedBy
+	experiment.Status.AbortReason = reason
+	setCondition(experiment, v1alpha1.ConditionAborted, metav1.ConditionTrue, conditionReason, message)
+	if len(experiment.Status.Targets) > 0 {
+		setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionTrue, v1alpha1.ReasonRecovered, "All targets recovered")
+	}
+
+	_, err := c.updateStatus(experiment)
+	return err
+}
+
+// pauseExperiment recovers every target of a running experiment and moves it to the paused phase
+func (c *Controller) pauseExperiment(experiment *v1alpha1.ChaosExperiment) error {
+	experiment = experiment.DeepCopy()
+	klog.Infof("Pausing chaos experiment %s/%s", experiment.Namespace, experiment.Name)
+
+	experimentImpl := experiments.ExperimentFactory(c.kubeclientset, c.injectors, experiment.Spec.ExperimentType)
+	if experimentImpl == nil {
+		return fmt.Errorf("unknown experiment type: %s", experiment.Spec.ExperimentType)
+	}
+
+	if err := c.recoverTargets(experimentImpl, experiment); err != nil {
+		experiment.Status.Message = fmt.Sprintf("Failed to recover targets while pausing: %v", err)
+		if _, updateErr := c.updateStatus(experiment); updateErr != nil {
+			klog.Errorf("Failed to update experiment status: %v", updateErr)
+		}
+		return fmt.Errorf("failed to recover targets: %v", err)
+	}
+
+	experiment.Status.Phase = v1alpha1.PhasePaused
+	now := metav1.NewTime(c.clock.Now())
+	experiment.Status.PausedAt = &now
+	experiment.Status.Message = "Experiment paused"
+	setCondition(experiment, v1alpha1.ConditionInjected, metav1.ConditionFalse, v1alpha1.ReasonPaused, "Experiment is paused")
+	setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionTrue, v1alpha1.ReasonRecovered, "All targets recovered")
+
+	_, err := c.updateStatus(experiment)
+	return err
+}
+
+// handlePausedExperiment injects the targets of a paused experiment again once it is resumed
+func (c *Controller) handlePausedExperiment(experiment *v1alpha1.ChaosExperiment) error {
+	if experiment.Spec.Paused {
+		return nil
+	}
+
+	experiment = experiment.DeepCopy()
+	klog.Infof("Resuming chaos experiment %s/%s", experiment.Namespace, experiment.Name)
+
+	experimentImpl := experiments.ExperimentFactory(c.kubeclientset, c.injectors, experiment.Spec.ExperimentType)
+	if experimentImpl == nil {
+		return fmt.Errorf("unknown experiment type: %s", experiment.Spec.ExperimentType)
+	}
+
+	if experiment.Status.PausedAt != nil {
+		experiment.Status.PausedDuration.Duration += c.clock.Since(experiment.Status.PausedAt.Time)
+		experiment.Status.PausedAt = nil
+	}
+
+	injected := 0
+	var failures []string
+	for i := range experiment.Status.Targets {
+		target := &experiment.Status.Targets[i]
+
+		// Targets that are gone or were replaced while paused are left alone
+		pod, err := c.kubeclientset.CoreV1().Pods(target.Namespace).Get(context.TODO(), target.Name, metav1.GetOptions{})
+		if err != nil || pod.UID != target.UID {
+			if err != nil && !errors.IsNotFound(err) {
+				target.Error = err.Error()
+				failures = append(failures, fmt.Sprintf("pod %s/%s: %v", target.Namespace, target.Name, err))
+			}
+			continue
+		}
+
+		target.InjectedAt = nil
+		target.RecoveredAt = nil
+		// startTarget records the error on the target
+		if err := c.startTarget(experimentImpl, experiment, pod); err != nil {
+			klog.Errorf("Failed to resume experiment %s/%s on pod %s: %v", experiment.Namespace, experiment.Name, pod.Name, err)
+			failures = append(failures, fmt.Sprintf("pod %s/%s: %v", pod.Namespace, pod.Name, err))
+			continue
+		}
+		injected++
+	}
+
+	if injected == 0 {
+		// Nothing is injected anymore, so the experiment cannot go on
+		message := "No target could be resumed, every target pod is gone or was replaced"
+		if len(failures) > 0 {
+			message = fmt.Sprintf("No target could be resumed: %s", strings.Join(failures, "; "))
+		}
+		c.failExperiment(experiment, v1alpha1.ConditionInjected, v1alpha1.ReasonInjectionFailed, message)
+		return nil
+	}
+
+	message := fmt.Sprintf("Injected %d pods", injected)
+	if len(failures) > 0 {
+		message = fmt.Sprintf("Injected %d pods, %d failed to resume: %s", injected, len(failures), strings.Join(failures, "; "))
+	}
+	experiment.Status.Phase = v1alpha1.PhaseRunning
+	experiment.Status.Message = "Experiment resumed"
+	setCondition(experiment, v1alpha1.ConditionInjected, metav1.ConditionTrue, v1alpha1.ReasonInjected, message)
+	setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionFalse, v1alpha1.ReasonNotRecovered, "Targets have not been recovered")
+
+	_, err := c.updateStatus(experiment)
+	return err
+}
@@ -7,48 +7,83 @@ import (
 
 	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
 	"github.com/chaos-engineering/controller/pkg/chaos/experiments"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
+	"github.com/chaos-engineering/controller/pkg/chaos/probe"
+	"github.com/chaos-engineering/controller/pkg/chaos/selector"
+	"github.com/chaos-engineering/controller/pkg/chaos/validation"
 	clientset "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned"
 	informers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions/chaos/v1alpha1"
 	listers "github.com/chaos-engineering/controller/pkg/generated/listers/chaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 )
 
 type Controller struct {
-	kubeclientset    kubernetes.Interface
-	chaosclientset   clientset.Interface
-	restConfig      *rest.Config
+	kubeclientset  kubernetes.Interface
+	chaosclientset clientset.Interface
+	restConfig     *rest.Config
 
 	experimentsLister listers.ChaosExperimentLister
 	experimentsSynced cache.InformerSynced
 
 	workqueue workqueue.RateLimitingInterface
 
-	// activeExperiments keeps track of running experiments
-	activeExperiments map[string]experiments.ChaosExperiment
+	// clock drives experiment durations and delayed requeues
+	clock clock.WithTicker
+
+	// podSelector resolves experiment targets into pods
+	podSelector *selector.PodSelector
+
+	// prober evaluates the steady state probes of experiments
+	prober *probe.Prober
+	// injectors run the commands of exec based experiments
+	injectors injector.Injectors
 }
 
 func NewController(
 	kubeclientset kubernetes.Interface,
 	chaosclientset clientset.Interface,
+	dynamicclient dynamic.Interface,
 	restConfig *rest.Config,
+	injectors injector.Injectors,
 	experimentInformer informers.ChaosExperimentInformer) *Controller {
+	return newController(kubeclientset, chaosclientset, dynamicclient, restConfig, injectors, experimentInformer, clock.RealClock{})
+}
+
+// newController creates a controller that measures time with the given clock
+func newController(
+	kubeclientset kubernetes.Interface,
+	chaosclientset clientset.Interface,
+	dynamicclient dynamic.Interface,
+	restConfig *rest.Config,
+	injectors injector.Injectors,
+	experimentInformer informers.ChaosExperimentInformer,
+	clock clock.WithTicker) *Controller {
 
 	controller := &Controller{
-		kubeclientset:    kubeclientset,
-		chaosclientset:   chaosclientset,
-		restConfig:      restConfig,
+		kubeclientset:     kubeclientset,
+		chaosclientset:    chaosclientset,
+		restConfig:        restConfig,
 		experimentsLister: experimentInformer.Lister(),
 		experimentsSynced: experimentInformer.Informer().HasSynced,
-		workqueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ChaosExperiments"),
-		activeExperiments: make(map[string]experiments.ChaosExperiment),
+		workqueue: workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{
+			Name:  "ChaosExperiments",
+			Clock: clock,
+		}),
+		clock:       clock,
+		podSelector: selector.NewPodSelector(kubeclientset),
+		prober:      probe.NewProber(kubeclientset, dynamicclient, restConfig),
+		injectors:   injectors,
 	}
 
 	klog.Info("Setting up event handlers")
@@ -57,6 +92,7 @@ func NewController(
 		UpdateFunc: func(old, new interface{}) {
 			controller.enqueueChaosExperiment(new)
 		},
+		DeleteFunc: controller.enqueueDeletedChaosExperiment,
 	})
 
 	return controller
@@ -72,6 +108,25 @@ func (c *Controller) enqueueChaosExperiment(obj interface{}) {
 	c.workqueue.Add(key)
 }
 
+// enqueueChaosExperimentAfter requeues the experiment once the delay has passed
+func (c *Controller) enqueueChaosExperimentAfter(obj interface{}, delay time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.workqueue.AddAfter(key, delay)
+}
+
+func (c *Controller) enqueueDeletedChaosExperiment(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
 func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
 	defer runtime.HandleCrash()
 	defer c.workqueue.ShutDown()
@@ -118,7 +173,9 @@ func (c *Controller) processNextWorkItem() bool {
 		}
 
 		if err := c.syncHandler(key); err != nil {
-			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+			// Put the item back on the workqueue to retry it with backoff
+			c.workqueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
 		}
 
 		c.workqueue.Forget(obj)
@@ -150,13 +207,38 @@ func (c *Controller) syncHandler(key string) error {
 		return err
 	}
 
+	if experiment.DeletionTimestamp != nil {
+		return c.handleDeletedExperiment(experiment)
+	}
+
 	switch experiment.Status.Phase {
 	case "", v1alpha1.PhasePending:
+		// Make sure the targets are recovered before the experiment can be deleted
+		if !hasFinalizer(experiment) {
+			return c.addFinalizer(experiment)
+		}
+		if abortRequested(experiment) {
+			return c.handleAbortedExperiment(experiment)
+		}
 		return c.handlePendingExperiment(experiment)
 	case v1alpha1.PhaseRunning:
+		if !hasFinalizer(experiment) {
+			return c.addFinalizer(experiment)
+		}
+		if abortRequested(experiment) {
+			return c.handleAbortedExperiment(experiment)
+		}
+		if experiment.Spec.Paused {
+			return c.pauseExperiment(experiment)
+		}
 		return c.handleRunningExperiment(experiment)
-	case v1alpha1.PhaseCompleted, v1alpha1.PhaseFailed:
-		// Nothing to do for completed/failed experiments
+	case v1alpha1.PhasePaused:
+		if abortRequested(experiment) {
+			return c.handleAbortedExperiment(experiment)
+		}
+		return c.handlePausedExperiment(experiment)
+	case v1alpha1.PhaseCompleted, v1alpha1.PhaseFailed, v1alpha1.PhaseAborted:
+		// Nothing to do for completed/failed/aborted experiments
 		return nil
 	default:
 		return fmt.Errorf("unknown experiment phase: %s", experiment.Status.Phase)
@@ -164,45 +246,121 @@ func (c *Controller) syncHandler(key string) error {
 }
 
 func (c *Controller) handlePendingExperiment(experiment *v1alpha1.ChaosExperiment) error {
-	experiment = experiment.DeepCopy()
-	experiment.Status.Phase = v1alpha1.PhaseRunning
-	experiment.Status.StartTime = &metav1.Time{Time: time.Now()}
-	experiment.Status.Message = "Experiment started"
-
-	_, err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).UpdateStatus(context.TODO(), experiment, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to update experiment status: %v", err)
+	// Experiments created paused wait until they are resumed
+	if experiment.Spec.Paused {
+		return nil
 	}
 
+	experiment = experiment.DeepCopy()
+
 	// Create and start the chaos experiment
 	klog.Infof("Starting chaos experiment %s/%s of type %s", experiment.Namespace, experiment.Name, experiment.Spec.ExperimentType)
-	
+
+	// Experiments created while the webhook was not running may still be invalid.
+	// Retrying cannot fix the spec, so the experiment fails without requeueing.
+	if errs := validation.ValidateChaosExperiment(experiment); len(errs) > 0 {
+		c.failExperiment(experiment, v1alpha1.ConditionValidated, v1alpha1.ReasonInvalidSpec,
+			fmt.Sprintf("Invalid experiment spec: %v", errs.ToAggregate()))
+		return nil
+	}
+
 	// Create the experiment
-	experimentImpl := experiments.ExperimentFactory(c.kubeclientset, c.restConfig, experiment.Spec.ExperimentType)
-	if experimentImpl == nil {
-		experiment.Status.Phase = v1alpha1.PhaseFailed
-		experiment.Status.Message = fmt.Sprintf("Unknown experiment type: %s", experiment.Spec.ExperimentType)
-		_, err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).UpdateStatus(context.TODO(), experiment, metav1.UpdateOptions{})
-		return fmt.Errorf("unknown experiment type: %s", experiment.Spec.ExperimentType)
+	experimentImpl := experiments.ExperimentFactory(c.kubeclientset, c.injectors, experiment.Spec.ExperimentType)
+	setCondition(experiment, v1alpha1.ConditionValidated, metav1.ConditionTrue, v1alpha1.ReasonValid, "Experiment spec is valid")
+
+	// Only inject a system that is healthy to begin with
+	if experiment.Spec.SteadyState != nil {
+		if message := c.runProbes(experiment, v1alpha1.ProbeStageBefore); message != "" {
+			c.failExperiment(experiment, v1alpha1.ConditionSteadyState, v1alpha1.ReasonSteadyStateNotMet, message)
+			return nil
+		}
 	}
 
-	// Start the experiment
-	err = experimentImpl.Start(context.TODO(), experiment)
+	// Resolve the target into the pods to inject
+	pods, err := c.podSelector.Select(context.TODO(), experiment)
 	if err != nil {
-		experiment.Status.Phase = v1alpha1.PhaseFailed
-		experiment.Status.Message = fmt.Sprintf("Failed to start experiment: %v", err)
-		_, updateErr := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).UpdateStatus(context.TODO(), experiment, metav1.UpdateOptions{})
-		if updateErr != nil {
-			klog.Errorf("Failed to update experiment status: %v", updateErr)
+		c.failExperiment(experiment, v1alpha1.ConditionSelected, v1alpha1.ReasonSelectionFailed,
+			fmt.Sprintf("Failed to select targets: %v", err))
+		return nil
+	}
+
+	// Narrow the targets down according to the mode, recording the seed for replays
+	seed := c.clock.Now().UnixNano()
+	if experiment.Spec.Seed != nil {
+		seed = *experiment.Spec.Seed
+	}
+	pods, err = selector.FilterByMode(pods, experiment.Spec.Mode, experiment.Spec.Value, seed)
+	if err != nil {
+		c.failExperiment(experiment, v1alpha1.ConditionSelected, v1alpha1.ReasonSelectionFailed,
+			fmt.Sprintf("Failed to select targets: %v", err))
+		return nil
+	}
+
+	// Every injected pod must have the containers to inject
+	if err := selector.CheckContainers(pods, experiment.Spec.Target.ContainerNames); err != nil {
+		c.failExperiment(experiment, v1alpha1.ConditionSelected, v1alpha1.ReasonSelectionFailed,
+			fmt.Sprintf("Failed to select targets: %v", err))
+		return nil
+	}
+
+	experiment.Status.Phase = v1alpha1.PhaseRunning
+	experiment.Status.StartTime = &metav1.Time{Time: c.clock.Now()}
+	experiment.Status.Message = "Experiment started"
+	experiment.Status.Seed = seed
+	experiment.Status.SelectedPods = make([]v1alpha1.PodReference, 0, len(pods))
+	experiment.Status.Targets = make([]v1alpha1.TargetStatus, 0, len(pods))
+	for _, pod := range pods {
+		ref := v1alpha1.PodReference{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			UID:       pod.UID,
 		}
-		return fmt.Errorf("failed to start experiment: %v", err)
+		experiment.Status.SelectedPods = append(experiment.Status.SelectedPods, ref)
+		experiment.Status.Targets = append(experiment.Status.Targets, v1alpha1.TargetStatus{
+			PodReference: ref,
+			Containers:   append([]string(nil), experiment.Spec.Target.ContainerNames...),
+		})
 	}
+	setCondition(experiment, v1alpha1.ConditionSelected, metav1.ConditionTrue, v1alpha1.ReasonPodsSelected,
+		fmt.Sprintf("Selected %d pods", len(pods)))
+	setCondition(experiment, v1alpha1.ConditionInjected, metav1.ConditionFalse, v1alpha1.ReasonNotInjected, "Injecting targets")
+	setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionFalse, v1alpha1.ReasonNotRecovered, "Targets have not been recovered")
+	setCondition(experiment, v1alpha1.ConditionAborted, metav1.ConditionFalse, v1alpha1.ReasonNotAborted, "Experiment has not been aborted")
 
-	// Store the experiment in the active experiments map
-	key := fmt.Sprintf("%s/%s", experiment.Namespace, experiment.Name)
-	c.activeExperiments[key] = experimentImpl
+	experiment, err = c.updateStatus(experiment)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	// Start the experiment on every selected pod
+	for i := range pods {
+		err = c.startTarget(experimentImpl, experiment, &pods[i])
+		if err != nil {
+			// Roll back the pods that were already injected
+			recovered := true
+			for j := 0; j < i; j++ {
+				if stopErr := c.stopTarget(experimentImpl, experiment, &pods[j]); stopErr != nil {
+					klog.Errorf("Failed to roll back experiment on pod %s/%s: %v", pods[j].Namespace, pods[j].Name, stopErr)
+					recovered = false
+				}
+			}
+			if recovered {
+				setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionTrue, v1alpha1.ReasonRecovered, "Injected targets were rolled back")
+			} else {
+				setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionFalse, v1alpha1.ReasonRecoveryFailed, "Some injected targets could not be rolled back")
+			}
+
+			c.failExperiment(experiment, v1alpha1.ConditionInjected, v1alpha1.ReasonInjectionFailed,
+				fmt.Sprintf("Failed to start experiment on pod %s/%s: %v", pods[i].Namespace, pods[i].Name, err))
+			return fmt.Errorf("failed to start experiment: %v", err)
+		}
+	}
+
+	// Record the injection times of the targets
+	setCondition(experiment, v1alpha1.ConditionInjected, metav1.ConditionTrue, v1alpha1.ReasonInjected,
+		fmt.Sprintf("Injected %d pods", len(pods)))
+	_, err = c.updateStatus(experiment)
+	return err
 }
 
 func (c *Controller) handleRunningExperiment(experiment *v1alpha1.ChaosExperiment) error {
@@ -211,39 +369,142 @@ func (c *Controller) handleRunningExperiment(experiment *v1alpha1.ChaosExperimen
 		return fmt.Errorf("invalid duration: %v", err)
 	}
 
-	// Check if the experiment has completed
-	if time.Since(experiment.Status.StartTime.Time) >= duration {
-		experiment = experiment.DeepCopy()
-		experiment.Status.Phase = v1alpha1.PhaseCompleted
-		now := metav1.Now()
-		experiment.Status.EndTime = &now
-		experiment.Status.Message = "Experiment completed successfully"
-
-		// Get the experiment from the active experiments map
-		key := fmt.Sprintf("%s/%s", experiment.Namespace, experiment.Name)
-		experimentImpl, exists := c.activeExperiments[key]
-		if exists {
-			// Stop the experiment
-			klog.Infof("Stopping chaos experiment %s/%s", experiment.Namespace, experiment.Name)
-			err = experimentImpl.Stop(context.TODO(), experiment)
-			if err != nil {
-				klog.Errorf("Failed to stop experiment %s/%s: %v", experiment.Namespace, experiment.Name, err)
-				experiment.Status.Message = fmt.Sprintf("Experiment completed with errors: %v", err)
-			}
-			
-			// Remove the experiment from the active experiments map
-			delete(c.activeExperiments, key)
+	// Wait for the experiment to run its full duration, waking up exactly when
+	// it elapses instead of on the next informer resync. Time spent paused
+	// does not count.
+	elapsed := c.clock.Since(experiment.Status.StartTime.Time) - experiment.Status.PausedDuration.Duration
+	if remaining := duration - elapsed; remaining > 0 {
+		if experiment.Spec.SteadyState != nil {
+			return c.probeRunningExperiment(experiment, remaining)
+		}
+		c.enqueueChaosExperimentAfter(experiment, remaining)
+		return nil
+	}
+
+	// The experiment has completed, recover every target
+	experiment = experiment.DeepCopy()
+
+	// The experiment implementations are stateless, so the targets can be
+	// recovered from the persisted status even after a controller restart
+	klog.Infof("Stopping chaos experiment %s/%s", experiment.Namespace, experiment.Name)
+	experimentImpl := experiments.ExperimentFactory(c.kubeclientset, c.injectors, experiment.Spec.ExperimentType)
+	if experimentImpl == nil {
+		return fmt.Errorf("unknown experiment type: %s", experiment.Spec.ExperimentType)
+	}
+
+	if err := c.recoverTargets(experimentImpl, experiment); err != nil {
+		// Stay running so that recovery is retried until every target is recovered
+		experiment.Status.Message = fmt.Sprintf("Failed to recover targets: %v", err)
+		setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionFalse, v1alpha1.ReasonRecoveryFailed, experiment.Status.Message)
+		if _, updateErr := c.updateStatus(experiment); updateErr != nil {
+			klog.Errorf("Failed to update experiment status: %v", updateErr)
+		}
+		return fmt.Errorf("failed to recover targets: %v", err)
+	}
+
+	experiment.Status.Phase = v1alpha1.PhaseCompleted
+	now := metav1.NewTime(c.clock.Now())
+	experiment.Status.EndTime = &now
+	experiment.Status.Message = "Experiment completed successfully"
+	setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionTrue, v1alpha1.ReasonRecovered, "All targets recovered")
+
+	// Check that the system is healthy again now that the targets are recovered
+	if experiment.Spec.SteadyState != nil {
+		c.runProbes(experiment, v1alpha1.ProbeStageAfter)
+		if experiment.Status.Result == v1alpha1.ResultFailed {
+			experiment.Status.Message = "Experiment completed, steady state hypothesis failed"
 		} else {
-			klog.Warningf("Experiment %s/%s not found in active experiments map", experiment.Namespace, experiment.Name)
+			experiment.Status.Result = v1alpha1.ResultPassed
+			experiment.Status.Message = "Experiment completed, steady state hypothesis passed"
 		}
+	}
 
-		_, err = c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).UpdateStatus(context.TODO(), experiment, metav1.UpdateOptions{})
+	_, err = c.updateStatus(experiment)
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("Completed chaos experiment %s/%s", experiment.Namespace, experiment.Name)
+
+	return nil
+}
+
+// startTarget starts the experiment on a single pod and records the outcome in its target status
+func (c *Controller) startTarget(experimentImpl experiments.ChaosExperiment, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
+	target := findTarget(experiment, pod)
+
+	err := experimentImpl.Start(context.TODO(), experiment, pod)
+	if target != nil {
+		if err != nil {
+			target.Error = err.Error()
+		} else {
+			now := metav1.NewTime(c.clock.Now())
+			target.InjectedAt = &now
+			target.Error = ""
+		}
+	}
+	return err
+}
+
+// stopTarget stops the experiment on a single pod and records the outcome in its target status
+func (c *Controller) stopTarget(experimentImpl experiments.ChaosExperiment, experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) error {
+	target := findTarget(experiment, pod)
+
+	err := experimentImpl.Stop(context.TODO(), experiment, pod)
+	if target != nil {
 		if err != nil {
-			return fmt.Errorf("failed to update experiment status: %v", err)
+			target.Error = err.Error()
+		} else {
+			now := metav1.NewTime(c.clock.Now())
+			target.RecoveredAt = &now
+			target.Error = ""
+		}
+	}
+	return err
+}
+
+// recoverTargets stops the experiment on every target that has not been recovered yet.
+// Targets without a recorded injection are stopped as well, since the controller may
+// have restarted between injecting a target and recording it. Stop is idempotent, so
+// this is safe to repeat. It returns the last recovery error, if any.
+func (c *Controller) recoverTargets(experimentImpl experiments.ChaosExperiment, experiment *v1alpha1.ChaosExperiment) error {
+	var lastErr error
+	for i := range experiment.Status.Targets {
+		target := &experiment.Status.Targets[i]
+		if target.RecoveredAt != nil {
+			continue
+		}
+
+		pod, err := c.kubeclientset.CoreV1().Pods(target.Namespace).Get(context.TODO(), target.Name, metav1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			target.Error = err.Error()
+			lastErr = fmt.Errorf("failed to get target pod %s/%s: %v", target.Namespace, target.Name, err)
+			continue
+		}
+
+		// A pod that is gone or was replaced no longer carries the injected fault
+		if errors.IsNotFound(err) || pod.UID != target.UID {
+			now := metav1.NewTime(c.clock.Now())
+			target.RecoveredAt = &now
+			target.Error = ""
+			continue
 		}
 
-		klog.Infof("Completed chaos experiment %s/%s", experiment.Namespace, experiment.Name)
+		if err := c.stopTarget(experimentImpl, experiment, pod); err != nil {
+			klog.Errorf("Failed to stop experiment %s/%s on pod %s: %v", experiment.Namespace, experiment.Name, pod.Name, err)
+			lastErr = err
+		}
 	}
+	return lastErr
+}
 
+// findTarget returns the target status recorded for a pod, or nil if there is none
+func findTarget(experiment *v1alpha1.ChaosExperiment, pod *corev1.Pod) *v1alpha1.TargetStatus {
+	for i := range experiment.Status.Targets {
+		target := &experiment.Status.Targets[i]
+		if target.Namespace == pod.Namespace && target.Name == pod.Name {
+			return target
+		}
+	}
 	return nil
 }
@@ -0,0 +1,257 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/executor"
+	"github.com/chaos-engineering/controller/pkg/chaos/injector"
+	chaosfake "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned/fake"
+	chaosinformers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// testStart is when the experiments of the tests start
+var testStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestController creates a controller backed by fake clients and the given clock
+func newTestController(t *testing.T, clock *clocktesting.FakeClock, experiment *v1alpha1.ChaosExperiment, kubeObjects ...runtime.Object) (*Controller, *chaosfake.Clientset) {
+	t.Helper()
+	kubeclientset := kubefake.NewSimpleClientset(kubeObjects...)
+	chaosclientset := chaosfake.NewSimpleClientset(experiment)
+	dynamicclient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	informerFactory := chaosinformers.NewSharedInformerFactory(chaosclientset, 0)
+
+	c := newController(kubeclientset, chaosclientset, dynamicclient, &rest.Config{}, injector.Injectors{},
+		informerFactory.Chaos().V1alpha1().ChaosExperiments(), clock)
+	t.Cleanup(c.workqueue.ShutDown)
+	return c, chaosclientset
+}
+
+// runningExperiment returns a pod-failure experiment that started at testStart on the pod
+func runningExperiment(pod *corev1.Pod) *v1alpha1.ChaosExperiment {
+	ref := v1alpha1.PodReference{Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID}
+	return &v1alpha1.ChaosExperiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "experiment", Namespace: "default"},
+		Spec: v1alpha1.ChaosExperimentSpec{
+			ExperimentType: "pod-failure",
+			Duration:       "1m",
+		},
+		Status: v1alpha1.ChaosExperimentStatus{
+			Phase:        v1alpha1.PhaseRunning,
+			StartTime:    &metav1.Time{Time: testStart},
+			SelectedPods: []v1alpha1.PodReference{ref},
+			Targets: []v1alpha1.TargetStatus{{
+				PodReference: ref,
+				InjectedAt:   &metav1.Time{Time: testStart},
+			}},
+		},
+	}
+}
+
+func testPod() *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", UID: "nginx-uid"}}
+}
+
+// fakeClockQueue is a work queue whose delayed adds are timed by a fake clock, so
+// that they land exactly when the clock is stepped past their delay
+type fakeClockQueue struct {
+	workqueue.RateLimitingInterface
+	clock *clocktesting.FakeClock
+}
+
+func (q *fakeClockQueue) AddAfter(item interface{}, duration time.Duration) {
+	if duration <= 0 {
+		q.Add(item)
+		return
+	}
+	q.clock.AfterFunc(duration, func() { q.Add(item) })
+}
+
+func TestHandleRunningExperimentRequeuesWhenDurationElapses(t *testing.T) {
+	pod := testPod()
+	experiment := runningExperiment(pod)
+	// Time spent paused does not count towards the duration
+	experiment.Status.PausedDuration = metav1.Duration{Duration: 5 * time.Second}
+
+	clock := clocktesting.NewFakeClock(testStart.Add(10 * time.Second))
+	c, _ := newTestController(t, clock, experiment, pod)
+	queue := &fakeClockQueue{RateLimitingInterface: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()), clock: clock}
+	t.Cleanup(queue.ShutDown)
+	c.workqueue = queue
+
+	if err := c.handleRunningExperiment(experiment); err != nil {
+		t.Fatalf("handleRunningExperiment failed: %v", err)
+	}
+	if queue.Len() != 0 {
+		t.Fatalf("experiment was requeued right away")
+	}
+	if !clock.HasWaiters() {
+		t.Fatalf("experiment was not requeued for when its duration elapses")
+	}
+
+	// 1m duration - (10s elapsed - 5s paused)
+	remaining := 55 * time.Second
+	clock.Step(remaining - time.Millisecond)
+	if queue.Len() != 0 {
+		t.Fatalf("experiment was requeued before its duration elapsed")
+	}
+
+	clock.Step(time.Millisecond)
+	if queue.Len() != 1 {
+		t.Fatalf("experiment was not requeued after %v", remaining)
+	}
+	key, _ := queue.Get()
+	if key != "default/experiment" {
+		t.Errorf("got key %v, want default/experiment", key)
+	}
+	queue.Done(key)
+}
+
+func TestHandleRunningExperimentRecoversTargets(t *testing.T) {
+	pod := testPod()
+	experiment := runningExperiment(pod)
+
+	now := testStart.Add(time.Minute + time.Second)
+	clock := clocktesting.NewFakeClock(now)
+	c, chaosclientset := newTestController(t, clock, experiment, pod)
+
+	if err := c.handleRunningExperiment(experiment); err != nil {
+		t.Fatalf("handleRunningExperiment failed: %v", err)
+	}
+
+	updated, err := chaosclientset.ChaosV1alpha1().ChaosExperiments("default").Get(context.Background(), "experiment", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get experiment: %v", err)
+	}
+	if updated.Status.Phase != v1alpha1.PhaseCompleted {
+		t.Errorf("got phase %s, want %s", updated.Status.Phase, v1alpha1.PhaseCompleted)
+	}
+	if updated.Status.EndTime == nil || !updated.Status.EndTime.Time.Equal(now) {
+		t.Errorf("got end time %v, want %v", updated.Status.EndTime, now)
+	}
+	target := updated.Status.Targets[0]
+	if target.RecoveredAt == nil || !target.RecoveredAt.Time.Equal(now) {
+		t.Errorf("got recovery time %v, want %v", target.RecoveredAt, now)
+	}
+	if c.workqueue.Len() != 0 {
+		t.Errorf("completed experiment was requeued")
+	}
+}
+
+// pausedExperiment returns a paused cpu-hog experiment whose targets are the pods
+func pausedExperiment(pods ...*corev1.Pod) *v1alpha1.ChaosExperiment {
+	experiment := &v1alpha1.ChaosExperiment{
+		ObjectMeta: metav1.ObjectMeta{Name: "experiment", Namespace: "default"},
+		Spec: v1alpha1.ChaosExperimentSpec{
+			ExperimentType: "cpu-hog",
+			Duration:       "1m",
+			Injector:       v1alpha1.InjectorExec,
+		},
+		Status: v1alpha1.ChaosExperimentStatus{
+			Phase:     v1alpha1.PhasePaused,
+			StartTime: &metav1.Time{Time: testStart},
+			PausedAt:  &metav1.Time{Time: testStart.Add(10 * time.Second)},
+		},
+	}
+	for _, pod := range pods {
+		ref := v1alpha1.PodReference{Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID}
+		experiment.Status.SelectedPods = append(experiment.Status.SelectedPods, ref)
+		experiment.Status.Targets = append(experiment.Status.Targets, v1alpha1.TargetStatus{
+			PodReference: ref,
+			InjectedAt:   &metav1.Time{Time: testStart},
+			RecoveredAt:  &metav1.Time{Time: testStart.Add(10 * time.Second)},
+		})
+	}
+	return experiment
+}
+
+func TestHandlePausedExperimentResumesTargets(t *testing.T) {
+	app := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "app-uid"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	broken := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "default", UID: "broken-uid"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+
+	tests := []struct {
+		name          string
+		pods          []*corev1.Pod
+		wantPhase     string
+		wantCondition metav1.ConditionStatus
+		wantReason    string
+	}{
+		{
+			name:          "some targets resume",
+			pods:          []*corev1.Pod{app, broken},
+			wantPhase:     v1alpha1.PhaseRunning,
+			wantCondition: metav1.ConditionTrue,
+			wantReason:    v1alpha1.ReasonInjected,
+		},
+		{
+			name:          "no target resumes",
+			pods:          []*corev1.Pod{broken},
+			wantPhase:     v1alpha1.PhaseFailed,
+			wantCondition: metav1.ConditionFalse,
+			wantReason:    v1alpha1.ReasonInjectionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			experiment := pausedExperiment(tt.pods...)
+			var objects []runtime.Object
+			for _, pod := range tt.pods {
+				objects = append(objects, pod)
+			}
+			c, chaosclientset := newTestController(t, clocktesting.NewFakeClock(testStart.Add(time.Minute)), experiment, objects...)
+			c.injectors = injector.Injectors{
+				v1alpha1.InjectorExec: injector.NewExecInjector(executor.NewFakeExecutor(func(req executor.Request) (executor.Result, error) {
+					if req.Pod == "broken" {
+						return executor.Result{Stderr: "stress: not found"}, &executor.ExitError{ExitCode: 127}
+					}
+					return executor.Result{}, nil
+				})),
+			}
+
+			if err := c.handlePausedExperiment(experiment); err != nil {
+				t.Fatalf("handlePausedExperiment failed: %v", err)
+			}
+
+			updated, err := chaosclientset.ChaosV1alpha1().ChaosExperiments("default").Get(context.Background(), "experiment", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get experiment: %v", err)
+			}
+			if updated.Status.Phase != tt.wantPhase {
+				t.Errorf("got phase %s, want %s", updated.Status.Phase, tt.wantPhase)
+			}
+			condition := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionInjected)
+			if condition == nil || condition.Status != tt.wantCondition || condition.Reason != tt.wantReason {
+				t.Fatalf("got Injected condition %+v, want status %s and reason %s", condition, tt.wantCondition, tt.wantReason)
+			}
+			if !strings.Contains(condition.Message, "pod default/broken") {
+				t.Errorf("condition message %q does not name the pod that failed to resume", condition.Message)
+			}
+			for _, target := range updated.Status.Targets {
+				if target.Name == "broken" && (target.Error == "" || target.InjectedAt != nil) {
+					t.Errorf("failed target was not recorded: %+v", target)
+				}
+				if target.Name == "app" && (target.Error != "" || target.InjectedAt == nil) {
+					t.Errorf("resumed target was not recorded: %+v", target)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/experiments"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// hasFinalizer reports whether the experiment carries the rollback finalizer
+func hasFinalizer(experiment *v1alpha1.ChaosExperiment) bool {
+	for _, finalizer := range experiment.Finalizers {
+		if finalizer == v1alpha1.ExperimentFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// addFinalizer adds the rollback finalizer to the experiment
+func (c *Controller) addFinalizer(experiment *v1alpha1.ChaosExperiment) error {
+	experiment = experiment.DeepCopy()
+	experiment.Finalizers = append(experiment.Finalizers, v1alpha1.ExperimentFinalizer)
+
+	_, err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).Update(context.TODO(), experiment, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to add finalizer: %v", err)
+	}
+	return nil
+}
+
+// removeFinalizer removes the rollback finalizer from the experiment, letting the deletion complete
+func (c *Controller) removeFinalizer(experiment *v1alpha1.ChaosExperiment) error {
+	experiment = experiment.DeepCopy()
+	finalizers := experiment.Finalizers[:0]
+	for _, finalizer := range experiment.Finalizers {
+		if finalizer != v1alpha1.ExperimentFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	experiment.Finalizers = finalizers
+
+	_, err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).Update(context.TODO(), experiment, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to remove finalizer: %v", err)
+	}
+	return nil
+}
+
+// handleDeletedExperiment recovers every injected target of an experiment that is
+// being deleted and only then releases the finalizer
+func (c *Controller) handleDeletedExperiment(experiment *v1alpha1.ChaosExperiment) error {
+	if !hasFinalizer(experiment) {
+		return nil
+	}
+
+	experiment = experiment.DeepCopy()
+	klog.Infof("Recovering targets of deleted chaos experiment %s/%s", experiment.Namespace, experiment.Name)
+
+	var recoverErr error
+	experimentImpl := experiments.ExperimentFactory(c.kubeclientset, c.injectors, experiment.Spec.ExperimentType)
+	if experimentImpl != nil {
+		recoverErr = c.recoverTargets(experimentImpl, experiment)
+	}
+
+	if recoverErr != nil {
+		if experiment.Annotations[v1alpha1.AnnotationForceDelete] != "true" {
+			setCondition(experiment, v1alpha1.ConditionRecovered, metav1.ConditionFalse, v1alpha1.ReasonRecoveryFailed, recoverErr.Error())
+			if _, err := c.updateStatus(experiment); err != nil {
+				klog.Errorf("Failed to update experiment status: %v", err)
+			}
+			return fmt.Errorf("failed to recover targets of deleted experiment: %v", recoverErr)
+		}
+		klog.Warningf("Force deleting experiment %s/%s with unrecovered targets: %v", experiment.Namespace, experiment.Name, recoverErr)
+	}
+
+	return c.removeFinalizer(experiment)
+}
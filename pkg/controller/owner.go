@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// controllerOwnerKey returns the queue key of the object's controlling owner when
+// it is of the given kind, unwrapping the tombstones of deleted objects
+func controllerOwnerKey(obj interface{}, kind string) (string, bool) {
+	object, ok := obj.(metav1.Object)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+			return "", false
+		}
+		object, ok = tombstone.Obj.(metav1.Object)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+			return "", false
+		}
+	}
+
+	ownerRef := metav1.GetControllerOf(object)
+	if ownerRef == nil || ownerRef.Kind != kind {
+		return "", false
+	}
+	return object.GetNamespace() + "/" + ownerRef.Name, true
+}
@@ -0,0 +1,426 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/validation"
+	clientset "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned"
+	informers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions/chaos/v1alpha1"
+	listers "github.com/chaos-engineering/controller/pkg/generated/listers/chaos/v1alpha1"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// defaultSuccessfulHistoryLimit is how many completed experiments a schedule keeps by default
+	defaultSuccessfulHistoryLimit = 3
+	// defaultFailedHistoryLimit is how many failed or aborted experiments a schedule keeps by default
+	defaultFailedHistoryLimit = 1
+	// maxMissedRuns is how many missed runs a schedule looks through for the most recent one
+	maxMissedRuns = 100
+	// replacePollInterval is how often a schedule checks whether the experiments it
+	// replaces are gone, besides being enqueued when they are deleted
+	replacePollInterval = 5 * time.Second
+)
+
+// ScheduleController starts chaos experiments on the cron schedule of ChaosSchedules
+type ScheduleController struct {
+	chaosclientset clientset.Interface
+
+	schedulesLister   listers.ChaosScheduleLister
+	schedulesSynced   cache.InformerSynced
+	experimentsLister listers.ChaosExperimentLister
+	experimentsSynced cache.InformerSynced
+
+	workqueue workqueue.RateLimitingInterface
+
+	// clock decides when schedules are due and drives the delayed requeues
+	clock clock.WithTicker
+}
+
+// NewScheduleController creates a controller for ChaosSchedules
+func NewScheduleController(
+	chaosclientset clientset.Interface,
+	scheduleInformer informers.ChaosScheduleInformer,
+	experimentInformer informers.ChaosExperimentInformer) *ScheduleController {
+	return newScheduleController(chaosclientset, scheduleInformer, experimentInformer, clock.RealClock{})
+}
+
+// newScheduleController creates a schedule controller that measures time with the given clock
+func newScheduleController(
+	chaosclientset clientset.Interface,
+	scheduleInformer informers.ChaosScheduleInformer,
+	experimentInformer informers.ChaosExperimentInformer,
+	clock clock.WithTicker) *ScheduleController {
+
+	controller := &ScheduleController{
+		chaosclientset:    chaosclientset,
+		schedulesLister:   scheduleInformer.Lister(),
+		schedulesSynced:   scheduleInformer.Informer().HasSynced,
+		experimentsLister: experimentInformer.Lister(),
+		experimentsSynced: experimentInformer.Informer().HasSynced,
+		workqueue: workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{
+			Name:  "ChaosSchedules",
+			Clock: clock,
+		}),
+		clock: clock,
+	}
+
+	scheduleInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueChaosSchedule,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueChaosSchedule(new)
+		},
+	})
+
+	// Experiments finishing free up their schedule for the next run
+	experimentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueOwningSchedule,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueOwningSchedule(new)
+		},
+		DeleteFunc: controller.enqueueOwningSchedule,
+	})
+
+	return controller
+}
+
+func (c *ScheduleController) enqueueChaosSchedule(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// enqueueOwningSchedule enqueues the schedule that created the experiment, if any
+func (c *ScheduleController) enqueueOwningSchedule(obj interface{}) {
+	if key, ok := controllerOwnerKey(obj, "ChaosSchedule"); ok {
+		c.workqueue.Add(key)
+	}
+}
+
+func (c *ScheduleController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer runtime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	klog.Info("Starting Chaos Schedule Controller")
+
+	if ok := cache.WaitForCacheSync(stopCh, c.schedulesSynced, c.experimentsSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	klog.Info("Shutting down schedule workers")
+
+	return nil
+}
+
+func (c *ScheduleController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *ScheduleController) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.workqueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		c.workqueue.Forget(obj)
+		runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := c.syncHandler(key); err != nil {
+		c.workqueue.AddRateLimited(key)
+		runtime.HandleError(fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error()))
+		return true
+	}
+
+	c.workqueue.Forget(obj)
+	return true
+}
+
+func (c *ScheduleController) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	schedule, err := c.schedulesLister.ChaosSchedules(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The experiments it created are garbage collected through their owner reference
+			return nil
+		}
+		return err
+	}
+	if schedule.DeletionTimestamp != nil {
+		return nil
+	}
+
+	schedule = schedule.DeepCopy()
+	now := c.clock.Now()
+
+	if errs := validation.ValidateChaosSchedule(schedule); len(errs) > 0 {
+		// Retrying cannot fix the spec, so the schedule waits for it to be edited
+		setScheduleCondition(schedule, v1alpha1.ConditionValidated, metav1.ConditionFalse, v1alpha1.ReasonInvalidSpec,
+			fmt.Sprintf("Invalid schedule spec: %v", errs.ToAggregate()))
+		return c.updateScheduleStatus(schedule)
+	}
+	setScheduleCondition(schedule, v1alpha1.ConditionValidated, metav1.ConditionTrue, v1alpha1.ReasonValid, "Schedule spec is valid")
+
+	experiments, err := c.experimentsLister.ChaosExperiments(namespace).List(labels.SelectorFromSet(labels.Set{v1alpha1.LabelSchedule: name}))
+	if err != nil {
+		return err
+	}
+
+	var active, successful, failed []*v1alpha1.ChaosExperiment
+	for _, experiment := range experiments {
+		if !metav1.IsControlledBy(experiment, schedule) {
+			continue
+		}
+		switch experiment.Status.Phase {
+		case v1alpha1.PhaseCompleted:
+			successful = append(successful, experiment)
+		case v1alpha1.PhaseFailed, v1alpha1.PhaseAborted:
+			failed = append(failed, experiment)
+		default:
+			active = append(active, experiment)
+		}
+	}
+
+	if err := c.pruneHistory(successful, historyLimit(schedule.Spec.SuccessfulExperimentsHistoryLimit, defaultSuccessfulHistoryLimit)); err != nil {
+		return err
+	}
+	if err := c.pruneHistory(failed, historyLimit(schedule.Spec.FailedExperimentsHistoryLimit, defaultFailedHistoryLimit)); err != nil {
+		return err
+	}
+
+	if schedule.Spec.Suspend {
+		schedule.Status.Active = experimentReferences(active)
+		return c.updateScheduleStatus(schedule)
+	}
+
+	// Validation guarantees the schedule parses
+	cronSchedule, _ := cron.ParseStandard(schedule.Spec.Schedule)
+	scheduledTime, err := mostRecentScheduleTime(schedule, cronSchedule, now)
+	if err != nil {
+		// Time alone cannot fix this, so the schedule waits for its spec to be edited
+		klog.Warningf("Chaos schedule %s/%s: %v", namespace, name, err)
+		setScheduleCondition(schedule, v1alpha1.ConditionScheduled, metav1.ConditionFalse, v1alpha1.ReasonTooManyMissedRuns, err.Error())
+		schedule.Status.Active = experimentReferences(active)
+		return c.updateScheduleStatus(schedule)
+	}
+	setScheduleCondition(schedule, v1alpha1.ConditionScheduled, metav1.ConditionTrue, v1alpha1.ReasonOnSchedule, "Runs are started on schedule")
+
+	replacing := false
+	if scheduledTime != nil {
+		active, replacing, err = c.startScheduledExperiment(schedule, *scheduledTime, now, active)
+		if err != nil {
+			return err
+		}
+	}
+
+	schedule.Status.Active = experimentReferences(active)
+	if err := c.updateScheduleStatus(schedule); err != nil {
+		return err
+	}
+
+	if replacing {
+		// Come back to start the run once the replaced experiments are gone
+		c.workqueue.AddAfter(key, replacePollInterval)
+		return nil
+	}
+	// Come back for the next run
+	c.workqueue.AddAfter(key, cronSchedule.Next(now).Sub(now))
+	return nil
+}
+
+// startScheduledExperiment starts the experiment of a scheduled run according to the
+// concurrency policy, returning the experiments that are active afterwards and
+// whether the run waits for the experiments it replaces to be deleted
+func (c *ScheduleController) startScheduledExperiment(schedule *v1alpha1.ChaosSchedule, scheduledTime, now time.Time,
+	active []*v1alpha1.ChaosExperiment) ([]*v1alpha1.ChaosExperiment, bool, error) {
+	if deadline := schedule.Spec.StartingDeadlineSeconds; deadline != nil && scheduledTime.Add(time.Duration(*deadline)*time.Second).Before(now) {
+		klog.Infof("Skipping run of chaos schedule %s/%s at %s, its starting deadline has passed", schedule.Namespace, schedule.Name, scheduledTime)
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+		return active, false, nil
+	}
+
+	switch schedule.Spec.ConcurrencyPolicy {
+	case "", v1alpha1.ConcurrencyForbid:
+		if len(active) > 0 {
+			// Try again once the active experiments finish, as long as the deadline allows
+			klog.V(2).Infof("Delaying run of chaos schedule %s/%s, %d experiments are still active", schedule.Namespace, schedule.Name, len(active))
+			return active, false, nil
+		}
+	case v1alpha1.ConcurrencyReplace:
+		if len(active) > 0 {
+			for _, experiment := range active {
+				if experiment.DeletionTimestamp != nil {
+					continue
+				}
+				// Deleting the experiment recovers its targets through its finalizer
+				err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).Delete(context.TODO(), experiment.Name, metav1.DeleteOptions{})
+				if err != nil && !errors.IsNotFound(err) {
+					return active, false, fmt.Errorf("failed to replace experiment %s: %v", experiment.Name, err)
+				}
+			}
+			// Only start the new experiment once the replaced ones have recovered their
+			// targets and are gone, so that their faults never overlap
+			klog.V(2).Infof("Delaying run of chaos schedule %s/%s until %d replaced experiments are deleted", schedule.Namespace, schedule.Name, len(active))
+			return active, true, nil
+		}
+	}
+
+	experiment := newScheduledExperiment(schedule, scheduledTime)
+	created, err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(schedule.Namespace).Create(context.TODO(), experiment, metav1.CreateOptions{})
+	switch {
+	case errors.IsAlreadyExists(err):
+		// Started by an earlier sync whose status update did not go through
+		created = experiment
+	case err != nil:
+		return active, false, fmt.Errorf("failed to create experiment: %v", err)
+	default:
+		klog.Infof("Started chaos experiment %s/%s for schedule %s", created.Namespace, created.Name, schedule.Name)
+	}
+
+	schedule.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	return append(active, created), false, nil
+}
+
+// pruneHistory deletes the oldest of the finished experiments beyond the limit
+func (c *ScheduleController) pruneHistory(experiments []*v1alpha1.ChaosExperiment, limit int) error {
+	if len(experiments) <= limit {
+		return nil
+	}
+
+	sort.Slice(experiments, func(i, j int) bool {
+		return experiments[i].CreationTimestamp.Before(&experiments[j].CreationTimestamp)
+	})
+	for _, experiment := range experiments[:len(experiments)-limit] {
+		err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).Delete(context.TODO(), experiment.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete experiment %s: %v", experiment.Name, err)
+		}
+	}
+	return nil
+}
+
+// updateScheduleStatus writes the schedule status, recording the observed generation
+func (c *ScheduleController) updateScheduleStatus(schedule *v1alpha1.ChaosSchedule) error {
+	schedule.Status.ObservedGeneration = schedule.Generation
+	_, err := c.chaosclientset.ChaosV1alpha1().ChaosSchedules(schedule.Namespace).UpdateStatus(context.TODO(), schedule, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update schedule status: %v", err)
+	}
+	return nil
+}
+
+// mostRecentScheduleTime returns the latest scheduled time that is due and has
+// not been handled yet, or nil when no run is due. Like the CronJob controller it
+// gives up when more than maxMissedRuns runs were missed, rather than walking every
+// run since the schedule was created.
+func mostRecentScheduleTime(schedule *v1alpha1.ChaosSchedule, cronSchedule cron.Schedule, now time.Time) (*time.Time, error) {
+	earliest := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		earliest = schedule.Status.LastScheduleTime.Time
+	}
+	// Runs that are past their starting deadline cannot be started anymore
+	if deadline := schedule.Spec.StartingDeadlineSeconds; deadline != nil {
+		if earliestStart := now.Add(-time.Duration(*deadline) * time.Second); earliestStart.After(earliest) {
+			earliest = earliestStart
+		}
+	}
+
+	var mostRecent *time.Time
+	missed := 0
+	for t := cronSchedule.Next(earliest); !t.After(now); t = cronSchedule.Next(t) {
+		missed++
+		if missed > maxMissedRuns {
+			return nil, fmt.Errorf("too many missed runs (more than %d), set or decrease startingDeadlineSeconds", maxMissedRuns)
+		}
+		scheduled := t
+		mostRecent = &scheduled
+	}
+	return mostRecent, nil
+}
+
+// newScheduledExperiment creates the experiment of the run scheduled at the given time.
+// The name is derived from the time so that a run is never started twice.
+func newScheduledExperiment(schedule *v1alpha1.ChaosSchedule, scheduledTime time.Time) *v1alpha1.ChaosExperiment {
+	return &v1alpha1.ChaosExperiment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", schedule.Name, scheduledTime.Unix()/60),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				v1alpha1.LabelSchedule: schedule.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(schedule, v1alpha1.SchemeGroupVersion.WithKind("ChaosSchedule")),
+			},
+		},
+		Spec: *schedule.Spec.ExperimentTemplate.DeepCopy(),
+	}
+}
+
+// experimentReferences returns references to the experiments, ordered by name
+func experimentReferences(experiments []*v1alpha1.ChaosExperiment) []corev1.ObjectReference {
+	refs := make([]corev1.ObjectReference, 0, len(experiments))
+	for _, experiment := range experiments {
+		refs = append(refs, corev1.ObjectReference{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "ChaosExperiment",
+			Namespace:  experiment.Namespace,
+			Name:       experiment.Name,
+			UID:        experiment.UID,
+		})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name < refs[j].Name
+	})
+	return refs
+}
+
+// setScheduleCondition sets a condition on the schedule status for its current generation
+func setScheduleCondition(schedule *v1alpha1.ChaosSchedule, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&schedule.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: schedule.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func historyLimit(limit *int32, defaultLimit int) int {
+	if limit == nil {
+		return defaultLimit
+	}
+	return int(*limit)
+}
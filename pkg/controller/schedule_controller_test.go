@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	chaosfake "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned/fake"
+	chaosinformers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions"
+	chaosv1alpha1informers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions/chaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// newTestScheduleController returns a schedule controller at testStart whose listers
+// hold the schedule and experiments
+func newTestScheduleController(t *testing.T, schedule *v1alpha1.ChaosSchedule, experiments ...*v1alpha1.ChaosExperiment) (*ScheduleController, *chaosfake.Clientset, chaosv1alpha1informers.ChaosExperimentInformer) {
+	t.Helper()
+	objects := []runtime.Object{schedule}
+	for _, experiment := range experiments {
+		objects = append(objects, experiment)
+	}
+	chaosclientset := chaosfake.NewSimpleClientset(objects...)
+	informerFactory := chaosinformers.NewSharedInformerFactory(chaosclientset, 0)
+	scheduleInformer := informerFactory.Chaos().V1alpha1().ChaosSchedules()
+	experimentInformer := informerFactory.Chaos().V1alpha1().ChaosExperiments()
+	c := newScheduleController(chaosclientset, scheduleInformer, experimentInformer, clocktesting.NewFakeClock(testStart))
+	t.Cleanup(c.workqueue.ShutDown)
+
+	if err := scheduleInformer.Informer().GetIndexer().Add(schedule); err != nil {
+		t.Fatal(err)
+	}
+	for _, experiment := range experiments {
+		if err := experimentInformer.Informer().GetIndexer().Add(experiment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c, chaosclientset, experimentInformer
+}
+
+// TestReplaceWaitsForReplacedExperiments checks that the Replace policy deletes the
+// running experiment and only starts the next one once it is gone
+func TestReplaceWaitsForReplacedExperiments(t *testing.T) {
+	schedule := &v1alpha1.ChaosSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			Namespace:         "default",
+			UID:               "schedule-uid",
+			CreationTimestamp: metav1.NewTime(testStart.Add(-time.Hour)),
+		},
+		Spec: v1alpha1.ChaosScheduleSpec{
+			Schedule:          "*/5 * * * *",
+			ConcurrencyPolicy: v1alpha1.ConcurrencyReplace,
+			ExperimentTemplate: v1alpha1.ChaosExperimentSpec{
+				Target:         v1alpha1.TargetResource{APIVersion: "v1", Kind: "Pod", Name: "nginx", Namespace: "default"},
+				ExperimentType: "pod-failure",
+				Duration:       "10m",
+			},
+		},
+		Status: v1alpha1.ChaosScheduleStatus{
+			LastScheduleTime: &metav1.Time{Time: testStart.Add(-5 * time.Minute)},
+		},
+	}
+	running := newScheduledExperiment(schedule, testStart.Add(-5*time.Minute))
+	running.Status.Phase = v1alpha1.PhaseRunning
+
+	c, chaosclientset, experimentInformer := newTestScheduleController(t, schedule, running)
+	// The finalizer keeps the experiment around while its targets are recovered
+	deleting := running.DeepCopy()
+
+	if err := c.syncHandler("default/nightly"); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if !hasAction(chaosclientset, "delete", running.Name) {
+		t.Errorf("running experiment was not deleted")
+	}
+	if hasAction(chaosclientset, "create", "") {
+		t.Fatalf("next experiment was created before the replaced one was gone")
+	}
+
+	chaosclientset.ClearActions()
+	now := metav1.NewTime(testStart)
+	deleting.DeletionTimestamp = &now
+	if err := experimentInformer.Informer().GetIndexer().Update(deleting); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.syncHandler("default/nightly"); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if hasAction(chaosclientset, "delete", "") || hasAction(chaosclientset, "create", "") {
+		t.Fatalf("schedule acted while the replaced experiment was being deleted")
+	}
+
+	chaosclientset.ClearActions()
+	if err := experimentInformer.Informer().GetIndexer().Delete(deleting); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.syncHandler("default/nightly"); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if !hasAction(chaosclientset, "create", "") {
+		t.Errorf("next experiment was not created once the replaced one was gone")
+	}
+}
+
+// TestTooManyMissedRuns checks that a schedule that missed too many runs stops with a
+// condition instead of walking every missed run, and resumes once a deadline is set
+func TestTooManyMissedRuns(t *testing.T) {
+	schedule := &v1alpha1.ChaosSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "every-minute",
+			Namespace:         "default",
+			UID:               "schedule-uid",
+			CreationTimestamp: metav1.NewTime(testStart.Add(-24 * time.Hour)),
+		},
+		Spec: v1alpha1.ChaosScheduleSpec{
+			Schedule: "* * * * *",
+			ExperimentTemplate: v1alpha1.ChaosExperimentSpec{
+				Target:         v1alpha1.TargetResource{APIVersion: "v1", Kind: "Pod", Name: "nginx", Namespace: "default"},
+				ExperimentType: "pod-failure",
+				Duration:       "30s",
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		deadlineSeconds *int64
+		wantStatus      metav1.ConditionStatus
+		wantReason      string
+		wantCreate      bool
+	}{
+		{
+			name:       "without a deadline",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: v1alpha1.ReasonTooManyMissedRuns,
+		},
+		{
+			name:            "with a deadline",
+			deadlineSeconds: func() *int64 { d := int64(300); return &d }(),
+			wantStatus:      metav1.ConditionTrue,
+			wantReason:      v1alpha1.ReasonOnSchedule,
+			wantCreate:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := schedule.DeepCopy()
+			schedule.Spec.StartingDeadlineSeconds = tt.deadlineSeconds
+			c, chaosclientset, _ := newTestScheduleController(t, schedule)
+
+			if err := c.syncHandler("default/every-minute"); err != nil {
+				t.Fatalf("sync failed: %v", err)
+			}
+			if got := hasAction(chaosclientset, "create", ""); got != tt.wantCreate {
+				t.Errorf("got experiment created %t, want %t", got, tt.wantCreate)
+			}
+			updated, err := chaosclientset.ChaosV1alpha1().ChaosSchedules("default").Get(context.TODO(), "every-minute", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			condition := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionScheduled)
+			if condition == nil || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("got condition %+v, want status %s and reason %s", condition, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+// hasAction reports whether the client made a request with the verb on chaos
+// experiments, for the named one unless name is empty
+func hasAction(clientset *chaosfake.Clientset, verb, name string) bool {
+	for _, action := range clientset.Actions() {
+		if !action.Matches(verb, "chaosexperiments") {
+			continue
+		}
+		if deleteAction, ok := action.(k8stesting.DeleteAction); ok && name != "" && deleteAction.GetName() != name {
+			continue
+		}
+		return true
+	}
+	return false
+}
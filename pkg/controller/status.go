@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// setCondition sets a condition on the experiment status for its current generation
+func setCondition(experiment *v1alpha1.ChaosExperiment, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&experiment.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: experiment.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// updateStatus writes the experiment status, recording the observed generation
+func (c *Controller) updateStatus(experiment *v1alpha1.ChaosExperiment) (*v1alpha1.ChaosExperiment, error) {
+	experiment.Status.ObservedGeneration = experiment.Generation
+	updated, err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).UpdateStatus(context.TODO(), experiment, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update experiment status: %v", err)
+	}
+	return updated, nil
+}
+
+// failExperiment moves the experiment to the failed phase with the given condition set to false
+func (c *Controller) failExperiment(experiment *v1alpha1.ChaosExperiment, conditionType, reason, message string) {
+	experiment.Status.Phase = v1alpha1.PhaseFailed
+	experiment.Status.Message = message
+	now := metav1.NewTime(c.clock.Now())
+	experiment.Status.EndTime = &now
+	setCondition(experiment, conditionType, metav1.ConditionFalse, reason, message)
+
+	if _, err := c.updateStatus(experiment); err != nil {
+		klog.Errorf("Failed to update experiment status: %v", err)
+	}
+}
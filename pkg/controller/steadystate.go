@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// defaultProbeInterval is how often the probes of a running experiment are evaluated
+// when its steady state sets no interval
+const defaultProbeInterval = 10 * time.Second
+
+// probeStages describes the stages of an experiment in status messages
+var probeStages = map[string]string{
+	v1alpha1.ProbeStageBefore: "before injection",
+	v1alpha1.ProbeStageDuring: "during injection",
+	v1alpha1.ProbeStageAfter:  "after recovery",
+}
+
+// runProbes evaluates every steady state probe of the experiment at the given stage and
+// records the evidence in its status. A failed probe fails the result of the experiment
+// for good. It returns a message describing the failed probes, or "" if all of them passed.
+func (c *Controller) runProbes(experiment *v1alpha1.ChaosExperiment, stage string) string {
+	now := metav1.NewTime(c.clock.Now())
+
+	var failures []string
+	for i := range experiment.Spec.SteadyState.Probes {
+		probe := &experiment.Spec.SteadyState.Probes[i]
+		evidence, err := c.prober.Run(context.TODO(), experiment.Namespace, probe)
+
+		status := v1alpha1.ProbeStatus{
+			Name:          probe.Name,
+			Stage:         stage,
+			Passed:        err == nil,
+			Message:       evidence,
+			LastProbeTime: &now,
+		}
+		if err != nil {
+			klog.Infof("Probe %s of chaos experiment %s/%s failed %s: %v", probe.Name, experiment.Namespace, experiment.Name, probeStages[stage], err)
+			status.Message = err.Error()
+			status.ConsecutiveFailures = 1
+			if previous := findProbeStatus(experiment, probe.Name); previous != nil {
+				status.ConsecutiveFailures += previous.ConsecutiveFailures
+			}
+			failures = append(failures, fmt.Sprintf("probe %s: %v", probe.Name, err))
+		}
+		setProbeStatus(experiment, status)
+	}
+
+	if len(failures) > 0 {
+		message := fmt.Sprintf("Steady state not met %s: %s", probeStages[stage], strings.Join(failures, "; "))
+		experiment.Status.Result = v1alpha1.ResultFailed
+		setCondition(experiment, v1alpha1.ConditionSteadyState, metav1.ConditionFalse, v1alpha1.ReasonSteadyStateNotMet, message)
+		return message
+	}
+	if experiment.Status.Result != v1alpha1.ResultFailed {
+		setCondition(experiment, v1alpha1.ConditionSteadyState, metav1.ConditionTrue, v1alpha1.ReasonSteadyStateMet,
+			"Steady state met "+probeStages[stage])
+	}
+	return ""
+}
+
+// probeRunningExperiment evaluates the probes of a running experiment when they are due
+// and requeues it for the next evaluation or the end of the experiment, whichever is first.
+// The experiment is aborted as soon as a probe reaches its abort threshold.
+func (c *Controller) probeRunningExperiment(experiment *v1alpha1.ChaosExperiment, remaining time.Duration) error {
+	// An abort whose recovery failed is retried without waiting for the next evaluation
+	if status := breachedProbe(experiment); status != nil {
+		return c.abortOnProbeFailure(experiment.DeepCopy(), status)
+	}
+
+	interval := probeInterval(experiment)
+	if next := interval - c.clock.Since(lastProbeTime(experiment)); next > 0 {
+		c.enqueueChaosExperimentAfter(experiment, min(next, remaining))
+		return nil
+	}
+
+	experiment = experiment.DeepCopy()
+	c.runProbes(experiment, v1alpha1.ProbeStageDuring)
+	if status := breachedProbe(experiment); status != nil {
+		return c.abortOnProbeFailure(experiment, status)
+	}
+	if _, err := c.updateStatus(experiment); err != nil {
+		return err
+	}
+
+	c.enqueueChaosExperimentAfter(experiment, min(interval, remaining))
+	return nil
+}
+
+// abortOnProbeFailure stops the experiment because of a probe that reached its abort
+// threshold, recording the evidence of the probe as the reason
+func (c *Controller) abortOnProbeFailure(experiment *v1alpha1.ChaosExperiment, status *v1alpha1.ProbeStatus) error {
+	reason := fmt.Sprintf("failed %d times in a row: %s", status.ConsecutiveFailures, status.Message)
+	return c.abortExperiment(experiment, "steady state probe "+status.Name, reason, v1alpha1.ReasonProbeFailed)
+}
+
+// breachedProbe returns the status of the first probe of the experiment that has failed
+// at least as many times in a row as its abort threshold, or nil if there is none
+func breachedProbe(experiment *v1alpha1.ChaosExperiment) *v1alpha1.ProbeStatus {
+	for _, probe := range experiment.Spec.SteadyState.Probes {
+		if probe.AbortThreshold <= 0 {
+			continue
+		}
+		status := findProbeStatus(experiment, probe.Name)
+		if status != nil && status.ConsecutiveFailures >= probe.AbortThreshold {
+			return status
+		}
+	}
+	return nil
+}
+
+// probeInterval returns how often the probes of a running experiment are evaluated
+func probeInterval(experiment *v1alpha1.ChaosExperiment) time.Duration {
+	if experiment.Spec.SteadyState.Interval == "" {
+		return defaultProbeInterval
+	}
+	// Validation guarantees the interval parses
+	interval, _ := time.ParseDuration(experiment.Spec.SteadyState.Interval)
+	return interval
+}
+
+// lastProbeTime returns when a probe of the experiment was last evaluated
+func lastProbeTime(experiment *v1alpha1.ChaosExperiment) time.Time {
+	var last time.Time
+	for _, status := range experiment.Status.Probes {
+		if status.LastProbeTime != nil && status.LastProbeTime.After(last) {
+			last = status.LastProbeTime.Time
+		}
+	}
+	return last
+}
+
+// findProbeStatus returns the status of the named probe, or nil if it has not been evaluated
+func findProbeStatus(experiment *v1alpha1.ChaosExperiment, name string) *v1alpha1.ProbeStatus {
+	for i := range experiment.Status.Probes {
+		if experiment.Status.Probes[i].Name == name {
+			return &experiment.Status.Probes[i]
+		}
+	}
+	return nil
+}
+
+// setProbeStatus records the status of a probe, replacing its previous status
+func setProbeStatus(experiment *v1alpha1.ChaosExperiment, status v1alpha1.ProbeStatus) {
+	if previous := findProbeStatus(experiment, status.Name); previous != nil {
+		*previous = status
+		return
+	}
+	experiment.Status.Probes = append(experiment.Status.Probes, status)
+}
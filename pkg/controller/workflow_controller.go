@@ -0,0 +1,546 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/validation"
+	clientset "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned"
+	informers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions/chaos/v1alpha1"
+	listers "github.com/chaos-engineering/controller/pkg/generated/listers/chaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+// WorkflowController runs the steps of ChaosWorkflows
+type WorkflowController struct {
+	chaosclientset clientset.Interface
+
+	workflowsLister   listers.ChaosWorkflowLister
+	workflowsSynced   cache.InformerSynced
+	experimentsLister listers.ChaosExperimentLister
+	experimentsSynced cache.InformerSynced
+
+	workqueue workqueue.RateLimitingInterface
+
+	// clock times Suspend steps and drives the delayed requeues
+	clock clock.WithTicker
+}
+
+// NewWorkflowController creates a controller for ChaosWorkflows
+func NewWorkflowController(
+	chaosclientset clientset.Interface,
+	workflowInformer informers.ChaosWorkflowInformer,
+	experimentInformer informers.ChaosExperimentInformer) *WorkflowController {
+	return newWorkflowController(chaosclientset, workflowInformer, experimentInformer, clock.RealClock{})
+}
+
+// newWorkflowController creates a workflow controller that measures time with the given clock
+func newWorkflowController(
+	chaosclientset clientset.Interface,
+	workflowInformer informers.ChaosWorkflowInformer,
+	experimentInformer informers.ChaosExperimentInformer,
+	clock clock.WithTicker) *WorkflowController {
+
+	controller := &WorkflowController{
+		chaosclientset:    chaosclientset,
+		workflowsLister:   workflowInformer.Lister(),
+		workflowsSynced:   workflowInformer.Informer().HasSynced,
+		experimentsLister: experimentInformer.Lister(),
+		experimentsSynced: experimentInformer.Informer().HasSynced,
+		workqueue: workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{
+			Name:  "ChaosWorkflows",
+			Clock: clock,
+		}),
+		clock: clock,
+	}
+
+	workflowInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueChaosWorkflow,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueChaosWorkflow(new)
+		},
+	})
+
+	// Experiments changing phase move their workflow on to the next steps
+	experimentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: controller.enqueueOwningWorkflow,
+		UpdateFunc: func(old, new interface{}) {
+			controller.enqueueOwningWorkflow(new)
+		},
+		DeleteFunc: controller.enqueueOwningWorkflow,
+	})
+
+	return controller
+}
+
+func (c *WorkflowController) enqueueChaosWorkflow(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// enqueueOwningWorkflow enqueues the workflow that created the experiment, if any
+func (c *WorkflowController) enqueueOwningWorkflow(obj interface{}) {
+	if key, ok := controllerOwnerKey(obj, "ChaosWorkflow"); ok {
+		c.workqueue.Add(key)
+	}
+}
+
+func (c *WorkflowController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer runtime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	klog.Info("Starting Chaos Workflow Controller")
+
+	if ok := cache.WaitForCacheSync(stopCh, c.workflowsSynced, c.experimentsSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	klog.Info("Shutting down workflow workers")
+
+	return nil
+}
+
+func (c *WorkflowController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *WorkflowController) processNextWorkItem() bool {
+	obj, shutdown := c.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.workqueue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		c.workqueue.Forget(obj)
+		runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := c.syncHandler(key); err != nil {
+		c.workqueue.AddRateLimited(key)
+		runtime.HandleError(fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error()))
+		return true
+	}
+
+	c.workqueue.Forget(obj)
+	return true
+}
+
+// workflowRun holds the state of a workflow while its steps are reconciled
+type workflowRun struct {
+	workflow    *v1alpha1.ChaosWorkflow
+	steps       map[string]*v1alpha1.WorkflowStep
+	statuses    map[string]*v1alpha1.WorkflowStepStatus
+	experiments map[string]*v1alpha1.ChaosExperiment
+	now         time.Time
+
+	// failedStep is the first Experiment step found to have failed
+	failedStep string
+	// requeueAfter is when the earliest running Suspend step ends
+	requeueAfter time.Duration
+}
+
+func (c *WorkflowController) syncHandler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	workflow, err := c.workflowsLister.ChaosWorkflows(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The experiments it created are garbage collected through their owner reference
+			return nil
+		}
+		return err
+	}
+	if workflow.DeletionTimestamp != nil || workflowFinished(workflow.Status.Phase) {
+		return nil
+	}
+
+	workflow = workflow.DeepCopy()
+	now := c.clock.Now()
+
+	if errs := validation.ValidateChaosWorkflow(workflow); len(errs) > 0 {
+		message := fmt.Sprintf("Invalid workflow spec: %v", errs.ToAggregate())
+		setWorkflowCondition(workflow, v1alpha1.ConditionValidated, metav1.ConditionFalse, v1alpha1.ReasonInvalidSpec, message)
+		c.finishWorkflow(workflow, v1alpha1.PhaseFailed, message, now)
+		return c.updateWorkflowStatus(workflow)
+	}
+	setWorkflowCondition(workflow, v1alpha1.ConditionValidated, metav1.ConditionTrue, v1alpha1.ReasonValid, "Workflow spec is valid")
+
+	if workflow.Status.Phase == "" || workflow.Status.Phase == v1alpha1.PhasePending {
+		klog.Infof("Starting chaos workflow %s/%s", namespace, name)
+		workflow.Status.Phase = v1alpha1.PhaseRunning
+		workflow.Status.StartTime = &metav1.Time{Time: now}
+		workflow.Status.Message = "Workflow started"
+	}
+
+	experiments, err := c.experimentsLister.ChaosExperiments(namespace).List(labels.SelectorFromSet(labels.Set{v1alpha1.LabelWorkflow: name}))
+	if err != nil {
+		return err
+	}
+	run := newWorkflowRun(workflow, experiments, now)
+
+	if workflow.Annotations[v1alpha1.AnnotationAbort] == "true" {
+		return c.abortWorkflow(run)
+	}
+
+	phase, err := c.reconcileStep(run, workflow.Spec.Entry)
+	if err != nil {
+		// Keep the progress made so far, such as experiments that were created
+		if updateErr := c.updateWorkflowStatus(workflow); updateErr != nil {
+			klog.Errorf("Failed to update workflow status: %v", updateErr)
+		}
+		return err
+	}
+
+	switch phase {
+	case v1alpha1.PhaseCompleted:
+		c.finishWorkflow(workflow, v1alpha1.PhaseCompleted, "All steps completed", now)
+	case v1alpha1.PhaseFailed:
+		message := fmt.Sprintf("Step %s failed", run.failedStep)
+		if err := c.abortActiveSteps(run, "ChaosWorkflow/"+name, message); err != nil {
+			if updateErr := c.updateWorkflowStatus(workflow); updateErr != nil {
+				klog.Errorf("Failed to update workflow status: %v", updateErr)
+			}
+			return err
+		}
+		c.finishWorkflow(workflow, v1alpha1.PhaseFailed, message, now)
+	default:
+		if run.requeueAfter > 0 {
+			c.workqueue.AddAfter(key, run.requeueAfter)
+		}
+	}
+
+	return c.updateWorkflowStatus(workflow)
+}
+
+// newWorkflowRun indexes the steps of the workflow, their status and the experiments
+// the workflow created. Steps without a status yet are added as pending.
+func newWorkflowRun(workflow *v1alpha1.ChaosWorkflow, experiments []*v1alpha1.ChaosExperiment, now time.Time) *workflowRun {
+	existing := make(map[string]v1alpha1.WorkflowStepStatus, len(workflow.Status.Steps))
+	for _, status := range workflow.Status.Steps {
+		existing[status.Name] = status
+	}
+
+	statuses := make([]v1alpha1.WorkflowStepStatus, 0, len(workflow.Spec.Steps))
+	for _, step := range workflow.Spec.Steps {
+		status, ok := existing[step.Name]
+		if !ok {
+			status = v1alpha1.WorkflowStepStatus{Name: step.Name, Phase: v1alpha1.PhasePending}
+		}
+		statuses = append(statuses, status)
+	}
+	workflow.Status.Steps = statuses
+
+	run := &workflowRun{
+		workflow:    workflow,
+		steps:       make(map[string]*v1alpha1.WorkflowStep, len(workflow.Spec.Steps)),
+		statuses:    make(map[string]*v1alpha1.WorkflowStepStatus, len(statuses)),
+		experiments: make(map[string]*v1alpha1.ChaosExperiment),
+		now:         now,
+	}
+	for i := range workflow.Spec.Steps {
+		run.steps[workflow.Spec.Steps[i].Name] = &workflow.Spec.Steps[i]
+		run.statuses[statuses[i].Name] = &statuses[i]
+	}
+	for _, experiment := range experiments {
+		if metav1.IsControlledBy(experiment, workflow) {
+			run.experiments[experiment.Labels[v1alpha1.LabelWorkflowStep]] = experiment
+		}
+	}
+	return run
+}
+
+// reconcileStep moves a step and its children forward, returning the phase of the step
+func (c *WorkflowController) reconcileStep(run *workflowRun, name string) (string, error) {
+	status := run.statuses[name]
+	if workflowFinished(status.Phase) {
+		return status.Phase, nil
+	}
+
+	step := run.steps[name]
+	if status.StartTime == nil {
+		status.StartTime = &metav1.Time{Time: run.now}
+		status.Phase = v1alpha1.PhaseRunning
+	}
+
+	switch step.Type {
+	case v1alpha1.StepTypeExperiment:
+		return c.reconcileExperimentStep(run, step, status)
+
+	case v1alpha1.StepTypeSerial:
+		for _, child := range step.Children {
+			phase, err := c.reconcileStep(run, child)
+			if err != nil {
+				return "", err
+			}
+			if phase != v1alpha1.PhaseCompleted {
+				return run.setStepPhase(status, phase, ""), nil
+			}
+		}
+		return run.setStepPhase(status, v1alpha1.PhaseCompleted, ""), nil
+
+	case v1alpha1.StepTypeParallel:
+		phase := v1alpha1.PhaseCompleted
+		for _, child := range step.Children {
+			childPhase, err := c.reconcileStep(run, child)
+			if err != nil {
+				return "", err
+			}
+			switch childPhase {
+			case v1alpha1.PhaseCompleted:
+			case v1alpha1.PhaseFailed, v1alpha1.PhaseAborted:
+				phase = v1alpha1.PhaseFailed
+			default:
+				if phase == v1alpha1.PhaseCompleted {
+					phase = v1alpha1.PhaseRunning
+				}
+			}
+		}
+		return run.setStepPhase(status, phase, ""), nil
+
+	case v1alpha1.StepTypeSuspend:
+		// Validation guarantees the duration parses
+		duration, _ := time.ParseDuration(step.Duration)
+		if remaining := status.StartTime.Add(duration).Sub(run.now); remaining > 0 {
+			if run.requeueAfter == 0 || remaining < run.requeueAfter {
+				run.requeueAfter = remaining
+			}
+			return v1alpha1.PhaseRunning, nil
+		}
+		return run.setStepPhase(status, v1alpha1.PhaseCompleted, ""), nil
+	}
+
+	return "", fmt.Errorf("unknown step type: %s", step.Type)
+}
+
+// reconcileExperimentStep creates the experiment of a step and follows its phase
+func (c *WorkflowController) reconcileExperimentStep(run *workflowRun, step *v1alpha1.WorkflowStep, status *v1alpha1.WorkflowStepStatus) (string, error) {
+	workflow := run.workflow
+
+	experiment, ok := run.experiments[step.Name]
+	if !ok && status.Experiment != "" {
+		// Created by an earlier sync, but possibly not in the cache yet
+		var err error
+		experiment, err = c.chaosclientset.ChaosV1alpha1().ChaosExperiments(workflow.Namespace).Get(context.TODO(), status.Experiment, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return run.failStep(status, fmt.Sprintf("Experiment %s was deleted", status.Experiment)), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if experiment == nil {
+		spec := step.Experiment
+		if step.ExperimentRef != nil {
+			template, err := c.experimentsLister.ChaosExperiments(workflow.Namespace).Get(step.ExperimentRef.Name)
+			if errors.IsNotFound(err) {
+				return run.failStep(status, fmt.Sprintf("Experiment %s not found", step.ExperimentRef.Name)), nil
+			}
+			if err != nil {
+				return "", err
+			}
+			// The referenced experiment serves as a template, typically created paused
+			// so that it does not run on its own. Its run state does not carry over.
+			spec = template.Spec.DeepCopy()
+			spec.Paused = false
+		}
+
+		experiment = newWorkflowExperiment(workflow, step.Name, spec)
+		_, err := c.chaosclientset.ChaosV1alpha1().ChaosExperiments(workflow.Namespace).Create(context.TODO(), experiment, metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("failed to create experiment for step %s: %v", step.Name, err)
+		}
+		klog.Infof("Started chaos experiment %s/%s for step %s of workflow %s", experiment.Namespace, experiment.Name, step.Name, workflow.Name)
+		run.experiments[step.Name] = experiment
+		status.Experiment = experiment.Name
+		status.Message = "Experiment created"
+		return v1alpha1.PhaseRunning, nil
+	}
+
+	switch experiment.Status.Phase {
+	case v1alpha1.PhaseCompleted:
+		return run.setStepPhase(status, v1alpha1.PhaseCompleted, experiment.Status.Message), nil
+	case v1alpha1.PhaseFailed, v1alpha1.PhaseAborted:
+		message := "Experiment " + strings.ToLower(experiment.Status.Phase)
+		if experiment.Status.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, experiment.Status.Message)
+		}
+		return run.failStep(status, message), nil
+	}
+	status.Message = experiment.Status.Message
+	return v1alpha1.PhaseRunning, nil
+}
+
+// setStepPhase records the phase of a step, ending it when the phase is final
+func (r *workflowRun) setStepPhase(status *v1alpha1.WorkflowStepStatus, phase, message string) string {
+	status.Phase = phase
+	if message != "" {
+		status.Message = message
+	}
+	if workflowFinished(phase) && status.EndTime == nil {
+		status.EndTime = &metav1.Time{Time: r.now}
+	}
+	return phase
+}
+
+// failStep fails an Experiment step, remembering the first one to fail
+func (r *workflowRun) failStep(status *v1alpha1.WorkflowStepStatus, message string) string {
+	if r.failedStep == "" {
+		r.failedStep = status.Name
+	}
+	return r.setStepPhase(status, v1alpha1.PhaseFailed, message)
+}
+
+// abortWorkflow aborts every active step and moves the workflow to the aborted phase
+func (c *WorkflowController) abortWorkflow(run *workflowRun) error {
+	workflow := run.workflow
+	abortedBy := workflow.Annotations[v1alpha1.AnnotationAbortedBy]
+	reason := workflow.Annotations[v1alpha1.AnnotationAbortReason]
+	klog.Infof("Aborting chaos workflow %s/%s (by %q: %q)", workflow.Namespace, workflow.Name, abortedBy, reason)
+
+	if err := c.abortActiveSteps(run, abortedBy, reason); err != nil {
+		if updateErr := c.updateWorkflowStatus(workflow); updateErr != nil {
+			klog.Errorf("Failed to update workflow status: %v", updateErr)
+		}
+		return err
+	}
+
+	message := "Workflow aborted"
+	if abortedBy != "" {
+		message = fmt.Sprintf("%s by %s", message, abortedBy)
+	}
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+	c.finishWorkflow(workflow, v1alpha1.PhaseAborted, message, run.now)
+	return c.updateWorkflowStatus(workflow)
+}
+
+// abortActiveSteps aborts the experiments of every running step, which rolls
+// back their targets, and moves the running steps to the aborted phase
+func (c *WorkflowController) abortActiveSteps(run *workflowRun, abortedBy, reason string) error {
+	for name, status := range run.statuses {
+		if status.Phase != v1alpha1.PhaseRunning {
+			continue
+		}
+
+		experiment, ok := run.experiments[name]
+		if !ok && status.Experiment != "" {
+			// Not in the cache yet, so abort it by name
+			experiment = &v1alpha1.ChaosExperiment{ObjectMeta: metav1.ObjectMeta{Namespace: run.workflow.Namespace, Name: status.Experiment}}
+		}
+		if experiment != nil && !workflowFinished(experiment.Status.Phase) && !abortRequested(experiment) {
+			if err := c.abortExperiment(experiment, abortedBy, reason); err != nil {
+				return err
+			}
+		}
+		run.setStepPhase(status, v1alpha1.PhaseAborted, "Step aborted")
+	}
+	return nil
+}
+
+// abortExperiment annotates the experiment for abort the same way the API server does.
+// Experiments that are already gone need no abort.
+func (c *WorkflowController) abortExperiment(experiment *v1alpha1.ChaosExperiment, abortedBy, reason string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				v1alpha1.AnnotationAbort:       "true",
+				v1alpha1.AnnotationAbortReason: reason,
+				v1alpha1.AnnotationAbortedBy:   abortedBy,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).Patch(context.TODO(), experiment.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to abort experiment %s: %v", experiment.Name, err)
+	}
+	return nil
+}
+
+// finishWorkflow moves the workflow to a final phase
+func (c *WorkflowController) finishWorkflow(workflow *v1alpha1.ChaosWorkflow, phase, message string, now time.Time) {
+	klog.Infof("Chaos workflow %s/%s finished: %s", workflow.Namespace, workflow.Name, message)
+	workflow.Status.Phase = phase
+	workflow.Status.Message = message
+	workflow.Status.EndTime = &metav1.Time{Time: now}
+}
+
+// updateWorkflowStatus writes the workflow status, recording the observed generation
+func (c *WorkflowController) updateWorkflowStatus(workflow *v1alpha1.ChaosWorkflow) error {
+	workflow.Status.ObservedGeneration = workflow.Generation
+	_, err := c.chaosclientset.ChaosV1alpha1().ChaosWorkflows(workflow.Namespace).UpdateStatus(context.TODO(), workflow, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update workflow status: %v", err)
+	}
+	return nil
+}
+
+// newWorkflowExperiment creates the experiment of an Experiment step
+func newWorkflowExperiment(workflow *v1alpha1.ChaosWorkflow, step string, spec *v1alpha1.ChaosExperimentSpec) *v1alpha1.ChaosExperiment {
+	return &v1alpha1.ChaosExperiment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", workflow.Name, step),
+			Namespace: workflow.Namespace,
+			Labels: map[string]string{
+				v1alpha1.LabelWorkflow:     workflow.Name,
+				v1alpha1.LabelWorkflowStep: step,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(workflow, v1alpha1.SchemeGroupVersion.WithKind("ChaosWorkflow")),
+			},
+		},
+		Spec: *spec.DeepCopy(),
+	}
+}
+
+// workflowFinished reports whether a workflow, step or experiment phase is final
+func workflowFinished(phase string) bool {
+	return phase == v1alpha1.PhaseCompleted || phase == v1alpha1.PhaseFailed || phase == v1alpha1.PhaseAborted
+}
+
+// setWorkflowCondition sets a condition on the workflow status for its current generation
+func setWorkflowCondition(workflow *v1alpha1.ChaosWorkflow, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&workflow.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: workflow.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
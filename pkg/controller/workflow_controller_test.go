@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	chaosfake "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned/fake"
+	chaosinformers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions"
+	chaosv1alpha1informers "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions/chaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// workflowTest runs a workflow controller against a fake clientset, copying what the
+// controller writes into the listers after every sync the way the informers would
+type workflowTest struct {
+	t                  *testing.T
+	c                  *WorkflowController
+	chaosclientset     *chaosfake.Clientset
+	clock              *clocktesting.FakeClock
+	workflowInformer   chaosv1alpha1informers.ChaosWorkflowInformer
+	experimentInformer chaosv1alpha1informers.ChaosExperimentInformer
+	workflow           *v1alpha1.ChaosWorkflow
+}
+
+func newWorkflowTest(t *testing.T, workflow *v1alpha1.ChaosWorkflow) *workflowTest {
+	t.Helper()
+	chaosclientset := chaosfake.NewSimpleClientset(workflow)
+	informerFactory := chaosinformers.NewSharedInformerFactory(chaosclientset, 0)
+	w := &workflowTest{
+		t:                  t,
+		chaosclientset:     chaosclientset,
+		clock:              clocktesting.NewFakeClock(testStart),
+		workflowInformer:   informerFactory.Chaos().V1alpha1().ChaosWorkflows(),
+		experimentInformer: informerFactory.Chaos().V1alpha1().ChaosExperiments(),
+		workflow:           workflow,
+	}
+	w.c = newWorkflowController(chaosclientset, w.workflowInformer, w.experimentInformer, w.clock)
+	t.Cleanup(w.c.workqueue.ShutDown)
+	w.refresh()
+	return w
+}
+
+// refresh copies the workflow and its experiments from the clientset into the listers
+func (w *workflowTest) refresh() {
+	w.t.Helper()
+	workflow, err := w.chaosclientset.ChaosV1alpha1().ChaosWorkflows(w.workflow.Namespace).Get(context.TODO(), w.workflow.Name, metav1.GetOptions{})
+	if err != nil {
+		w.t.Fatal(err)
+	}
+	if err := w.workflowInformer.Informer().GetIndexer().Update(workflow); err != nil {
+		w.t.Fatal(err)
+	}
+	w.workflow = workflow
+
+	experiments, err := w.chaosclientset.ChaosV1alpha1().ChaosExperiments(w.workflow.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		w.t.Fatal(err)
+	}
+	for i := range experiments.Items {
+		if err := w.experimentInformer.Informer().GetIndexer().Update(&experiments.Items[i]); err != nil {
+			w.t.Fatal(err)
+		}
+	}
+}
+
+// sync syncs the workflow and returns it as written by the controller
+func (w *workflowTest) sync() *v1alpha1.ChaosWorkflow {
+	w.t.Helper()
+	if err := w.c.syncHandler(w.workflow.Namespace + "/" + w.workflow.Name); err != nil {
+		w.t.Fatalf("sync failed: %v", err)
+	}
+	w.refresh()
+	return w.workflow
+}
+
+// experiment returns the experiment the workflow created for the step, or nil
+func (w *workflowTest) experiment(step string) *v1alpha1.ChaosExperiment {
+	w.t.Helper()
+	experiment, err := w.chaosclientset.ChaosV1alpha1().ChaosExperiments(w.workflow.Namespace).Get(context.TODO(), w.workflow.Name+"-"+step, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	return experiment
+}
+
+// finishExperiment moves the experiment of the step to the phase
+func (w *workflowTest) finishExperiment(step, phase string) {
+	w.t.Helper()
+	experiment := w.experiment(step)
+	if experiment == nil {
+		w.t.Fatalf("step %s has no experiment", step)
+	}
+	experiment.Status.Phase = phase
+	if _, err := w.chaosclientset.ChaosV1alpha1().ChaosExperiments(experiment.Namespace).UpdateStatus(context.TODO(), experiment, metav1.UpdateOptions{}); err != nil {
+		w.t.Fatal(err)
+	}
+	w.refresh()
+}
+
+// stepPhase returns the phase of the step in the workflow status
+func stepPhase(workflow *v1alpha1.ChaosWorkflow, step string) string {
+	for _, status := range workflow.Status.Steps {
+		if status.Name == step {
+			return status.Phase
+		}
+	}
+	return ""
+}
+
+// testWorkflow returns a workflow at testStart with the entry and steps. Steps
+// without a type are Experiment steps that fail a pod.
+func testWorkflow(entry string, steps ...v1alpha1.WorkflowStep) *v1alpha1.ChaosWorkflow {
+	for i := range steps {
+		if steps[i].Type == "" {
+			steps[i].Type = v1alpha1.StepTypeExperiment
+			steps[i].Experiment = &v1alpha1.ChaosExperimentSpec{
+				Target:         v1alpha1.TargetResource{APIVersion: "v1", Kind: "Pod", Name: "nginx", Namespace: "default"},
+				ExperimentType: "pod-failure",
+				Duration:       "1m",
+			}
+		}
+	}
+	return &v1alpha1.ChaosWorkflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "gameday",
+			Namespace:         "default",
+			UID:               "workflow-uid",
+			CreationTimestamp: metav1.NewTime(testStart),
+		},
+		Spec: v1alpha1.ChaosWorkflowSpec{Entry: entry, Steps: steps},
+	}
+}
+
+// TestWorkflowRunsSteps checks that Parallel children run together, Serial children
+// one after another, and that a Suspend step holds its siblings back for its duration
+func TestWorkflowRunsSteps(t *testing.T) {
+	w := newWorkflowTest(t, testWorkflow("main",
+		v1alpha1.WorkflowStep{Name: "main", Type: v1alpha1.StepTypeSerial, Children: []string{"warmup", "pause", "checkout"}},
+		v1alpha1.WorkflowStep{Name: "warmup", Type: v1alpha1.StepTypeParallel, Children: []string{"cart", "search"}},
+		v1alpha1.WorkflowStep{Name: "pause", Type: v1alpha1.StepTypeSuspend, Duration: "5m"},
+		v1alpha1.WorkflowStep{Name: "cart"},
+		v1alpha1.WorkflowStep{Name: "search"},
+		v1alpha1.WorkflowStep{Name: "checkout"},
+	))
+
+	workflow := w.sync()
+	if workflow.Status.Phase != v1alpha1.PhaseRunning {
+		t.Fatalf("got workflow phase %q, want %q", workflow.Status.Phase, v1alpha1.PhaseRunning)
+	}
+	if w.experiment("cart") == nil || w.experiment("search") == nil {
+		t.Fatalf("parallel steps were not started together")
+	}
+	if w.experiment("checkout") != nil {
+		t.Fatalf("serial step started before the steps ahead of it")
+	}
+
+	w.finishExperiment("cart", v1alpha1.PhaseCompleted)
+	workflow = w.sync()
+	if got := stepPhase(workflow, "warmup"); got != v1alpha1.PhaseRunning {
+		t.Fatalf("got warmup phase %q with a child running, want %q", got, v1alpha1.PhaseRunning)
+	}
+
+	w.finishExperiment("search", v1alpha1.PhaseCompleted)
+	workflow = w.sync()
+	if got := stepPhase(workflow, "warmup"); got != v1alpha1.PhaseCompleted {
+		t.Fatalf("got warmup phase %q, want %q", got, v1alpha1.PhaseCompleted)
+	}
+	if got := stepPhase(workflow, "pause"); got != v1alpha1.PhaseRunning {
+		t.Fatalf("got pause phase %q, want %q", got, v1alpha1.PhaseRunning)
+	}
+
+	w.clock.Step(5*time.Minute - time.Second)
+	w.sync()
+	if w.experiment("checkout") != nil {
+		t.Fatalf("serial step started before the suspend step ended")
+	}
+
+	w.clock.Step(time.Second)
+	workflow = w.sync()
+	if got := stepPhase(workflow, "pause"); got != v1alpha1.PhaseCompleted {
+		t.Fatalf("got pause phase %q, want %q", got, v1alpha1.PhaseCompleted)
+	}
+	if w.experiment("checkout") == nil {
+		t.Fatalf("serial step was not started once the suspend step ended")
+	}
+
+	w.finishExperiment("checkout", v1alpha1.PhaseCompleted)
+	workflow = w.sync()
+	if workflow.Status.Phase != v1alpha1.PhaseCompleted {
+		t.Errorf("got workflow phase %q, want %q", workflow.Status.Phase, v1alpha1.PhaseCompleted)
+	}
+	if workflow.Status.EndTime == nil || !workflow.Status.EndTime.Time.Equal(w.clock.Now()) {
+		t.Errorf("got end time %v, want %v", workflow.Status.EndTime, w.clock.Now())
+	}
+}
+
+// TestWorkflowFailedStepAbortsSiblings checks that a failed step aborts the
+// experiments of the steps still running and fails the workflow
+func TestWorkflowFailedStepAbortsSiblings(t *testing.T) {
+	w := newWorkflowTest(t, testWorkflow("main",
+		v1alpha1.WorkflowStep{Name: "main", Type: v1alpha1.StepTypeParallel, Children: []string{"cart", "search"}},
+		v1alpha1.WorkflowStep{Name: "cart"},
+		v1alpha1.WorkflowStep{Name: "search"},
+	))
+	w.sync()
+
+	w.finishExperiment("cart", v1alpha1.PhaseFailed)
+	workflow := w.sync()
+	if workflow.Status.Phase != v1alpha1.PhaseFailed || workflow.Status.Message != "Step cart failed" {
+		t.Errorf("got workflow phase %q (%q), want %q (%q)", workflow.Status.Phase, workflow.Status.Message, v1alpha1.PhaseFailed, "Step cart failed")
+	}
+	if got := stepPhase(workflow, "cart"); got != v1alpha1.PhaseFailed {
+		t.Errorf("got cart phase %q, want %q", got, v1alpha1.PhaseFailed)
+	}
+	if got := stepPhase(workflow, "search"); got != v1alpha1.PhaseAborted {
+		t.Errorf("got search phase %q, want %q", got, v1alpha1.PhaseAborted)
+	}
+
+	search := w.experiment("search")
+	if search.Annotations[v1alpha1.AnnotationAbort] != "true" {
+		t.Fatalf("running sibling was not aborted")
+	}
+	if got := search.Annotations[v1alpha1.AnnotationAbortedBy]; got != "ChaosWorkflow/gameday" {
+		t.Errorf("got aborted-by %q, want %q", got, "ChaosWorkflow/gameday")
+	}
+	if w.experiment("cart").Annotations[v1alpha1.AnnotationAbort] != "" {
+		t.Errorf("failed experiment was aborted as well")
+	}
+}
+
+// TestWorkflowAbortAnnotation checks that annotating a workflow aborts the
+// experiments of its running steps and the workflow
+func TestWorkflowAbortAnnotation(t *testing.T) {
+	w := newWorkflowTest(t, testWorkflow("main",
+		v1alpha1.WorkflowStep{Name: "main", Type: v1alpha1.StepTypeSerial, Children: []string{"cart", "search"}},
+		v1alpha1.WorkflowStep{Name: "cart"},
+		v1alpha1.WorkflowStep{Name: "search"},
+	))
+	w.sync()
+
+	annotated := w.workflow.DeepCopy()
+	annotated.Annotations = map[string]string{
+		v1alpha1.AnnotationAbort:       "true",
+		v1alpha1.AnnotationAbortedBy:   "alice",
+		v1alpha1.AnnotationAbortReason: "checkout is degraded",
+	}
+	if _, err := w.chaosclientset.ChaosV1alpha1().ChaosWorkflows(annotated.Namespace).Update(context.TODO(), annotated, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	w.refresh()
+
+	workflow := w.sync()
+	want := "Workflow aborted by alice: checkout is degraded"
+	if workflow.Status.Phase != v1alpha1.PhaseAborted || workflow.Status.Message != want {
+		t.Errorf("got workflow phase %q (%q), want %q (%q)", workflow.Status.Phase, workflow.Status.Message, v1alpha1.PhaseAborted, want)
+	}
+	for _, step := range []string{"main", "cart"} {
+		if got := stepPhase(workflow, step); got != v1alpha1.PhaseAborted {
+			t.Errorf("got %s phase %q, want %q", step, got, v1alpha1.PhaseAborted)
+		}
+	}
+	if got := stepPhase(workflow, "search"); got != v1alpha1.PhasePending {
+		t.Errorf("got search phase %q, want %q", got, v1alpha1.PhasePending)
+	}
+
+	cart := w.experiment("cart")
+	if cart.Annotations[v1alpha1.AnnotationAbort] != "true" || cart.Annotations[v1alpha1.AnnotationAbortedBy] != "alice" {
+		t.Errorf("running experiment was not aborted by alice: %v", cart.Annotations)
+	}
+	if w.experiment("search") != nil {
+		t.Errorf("pending step was started")
+	}
+
+	// A finished workflow is left alone
+	w.chaosclientset.ClearActions()
+	w.sync()
+	for _, action := range w.chaosclientset.Actions() {
+		if action.GetVerb() != "get" && action.GetVerb() != "list" {
+			t.Errorf("aborted workflow was synced again: %v", action)
+		}
+	}
+}
+
+// TestWorkflowNameTooLongForExperiments checks that a workflow whose name cannot
+// label its experiments fails validation instead of failing to create them
+func TestWorkflowNameTooLongForExperiments(t *testing.T) {
+	workflow := testWorkflow("cart", v1alpha1.WorkflowStep{Name: "cart"})
+	workflow.Name = strings.Repeat("a", 64)
+	w := newWorkflowTest(t, workflow)
+
+	workflow = w.sync()
+	if workflow.Status.Phase != v1alpha1.PhaseFailed {
+		t.Errorf("got workflow phase %q, want %q", workflow.Status.Phase, v1alpha1.PhaseFailed)
+	}
+	condition := meta.FindStatusCondition(workflow.Status.Conditions, v1alpha1.ConditionValidated)
+	if condition == nil || condition.Status != metav1.ConditionFalse || !strings.Contains(condition.Message, "metadata.name") {
+		t.Errorf("got condition %+v, want the name to be invalid", condition)
+	}
+	if hasAction(w.chaosclientset, "create", "") {
+		t.Errorf("experiment was created")
+	}
+}
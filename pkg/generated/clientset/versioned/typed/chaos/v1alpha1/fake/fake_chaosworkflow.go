@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The Chaos Engineering Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeChaosWorkflows implements ChaosWorkflowInterface
+type FakeChaosWorkflows struct {
+	Fake *FakeChaosV1alpha1
+	ns   string
+}
+
+var chaosworkflowsResource = v1alpha1.SchemeGroupVersion.WithResource("chaosworkflows")
+
+var chaosworkflowsKind = v1alpha1.SchemeGroupVersion.WithKind("ChaosWorkflow")
+
+// Get takes name of the chaosWorkflow, and returns the corresponding chaosWorkflow object, and an error if there is any.
+func (c *FakeChaosWorkflows) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ChaosWorkflow, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(chaosworkflowsResource, c.ns, name), &v1alpha1.ChaosWorkflow{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ChaosWorkflow), err
+}
+
+// List takes label and field selectors, and returns the list of ChaosWorkflows that match those selectors.
+func (c *FakeChaosWorkflows) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ChaosWorkflowList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(chaosworkflowsResource, chaosworkflowsKind, c.ns, opts), &v1alpha1.ChaosWorkflowList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ChaosWorkflowList{ListMeta: obj.(*v1alpha1.ChaosWorkflowList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ChaosWorkflowList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested chaosWorkflows.
+func (c *FakeChaosWorkflows) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(chaosworkflowsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a chaosWorkflow and creates it.  Returns the server's representation of the chaosWorkflow, and an error, if there is any.
+func (c *FakeChaosWorkflows) Create(ctx context.Context, chaosWorkflow *v1alpha1.ChaosWorkflow, opts v1.CreateOptions) (result *v1alpha1.ChaosWorkflow, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(chaosworkflowsResource, c.ns, chaosWorkflow), &v1alpha1.ChaosWorkflow{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ChaosWorkflow), err
+}
+
+// Update takes the representation of a chaosWorkflow and updates it. Returns the server's representation of the chaosWorkflow, and an error, if there is any.
+func (c *FakeChaosWorkflows) Update(ctx context.Context, chaosWorkflow *v1alpha1.ChaosWorkflow, opts v1.UpdateOptions) (result *v1alpha1.ChaosWorkflow, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(chaosworkflowsResource, c.ns, chaosWorkflow), &v1alpha1.ChaosWorkflow{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ChaosWorkflow), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeChaosWorkflows) UpdateStatus(ctx context.Context, chaosWorkflow *v1alpha1.ChaosWorkflow, opts v1.UpdateOptions) (*v1alpha1.ChaosWorkflow, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(chaosworkflowsResource, "status", c.ns, chaosWorkflow), &v1alpha1.ChaosWorkflow{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ChaosWorkflow), err
+}
+
+// Delete takes name of the chaosWorkflow and deletes it. Returns an error if one occurs.
+func (c *FakeChaosWorkflows) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(chaosworkflowsResource, c.ns, name, opts), &v1alpha1.ChaosWorkflow{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeChaosWorkflows) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(chaosworkflowsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ChaosWorkflowList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched chaosWorkflow.
+func (c *FakeChaosWorkflows) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ChaosWorkflow, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(chaosworkflowsResource, c.ns, name, pt, data, subresources...), &v1alpha1.ChaosWorkflow{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ChaosWorkflow), err
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Chaos Engineering Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	chaosv1alpha1 "github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	versioned "github.com/chaos-engineering/controller/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/chaos-engineering/controller/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/chaos-engineering/controller/pkg/generated/listers/chaos/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ChaosScheduleInformer provides access to a shared informer and lister for
+// ChaosSchedules.
+type ChaosScheduleInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ChaosScheduleLister
+}
+
+type chaosScheduleInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewChaosScheduleInformer constructs a new informer for ChaosSchedule type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewChaosScheduleInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredChaosScheduleInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredChaosScheduleInformer constructs a new informer for ChaosSchedule type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredChaosScheduleInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ChaosV1alpha1().ChaosSchedules(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ChaosV1alpha1().ChaosSchedules(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&chaosv1alpha1.ChaosSchedule{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *chaosScheduleInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredChaosScheduleInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *chaosScheduleInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&chaosv1alpha1.ChaosSchedule{}, f.defaultInformer)
+}
+
+func (f *chaosScheduleInformer) Lister() v1alpha1.ChaosScheduleLister {
+	return v1alpha1.NewChaosScheduleLister(f.Informer().GetIndexer())
+}
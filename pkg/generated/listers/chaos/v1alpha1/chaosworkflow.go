@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Chaos Engineering Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ChaosWorkflowLister helps list ChaosWorkflows.
+// All objects returned here must be treated as read-only.
+type ChaosWorkflowLister interface {
+	// List lists all ChaosWorkflows in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ChaosWorkflow, err error)
+	// ChaosWorkflows returns an object that can list and get ChaosWorkflows.
+	ChaosWorkflows(namespace string) ChaosWorkflowNamespaceLister
+	ChaosWorkflowListerExpansion
+}
+
+// chaosWorkflowLister implements the ChaosWorkflowLister interface.
+type chaosWorkflowLister struct {
+	indexer cache.Indexer
+}
+
+// NewChaosWorkflowLister returns a new ChaosWorkflowLister.
+func NewChaosWorkflowLister(indexer cache.Indexer) ChaosWorkflowLister {
+	return &chaosWorkflowLister{indexer: indexer}
+}
+
+// List lists all ChaosWorkflows in the indexer.
+func (s *chaosWorkflowLister) List(selector labels.Selector) (ret []*v1alpha1.ChaosWorkflow, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ChaosWorkflow))
+	})
+	return ret, err
+}
+
+// ChaosWorkflows returns an object that can list and get ChaosWorkflows.
+func (s *chaosWorkflowLister) ChaosWorkflows(namespace string) ChaosWorkflowNamespaceLister {
+	return chaosWorkflowNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ChaosWorkflowNamespaceLister helps list and get ChaosWorkflows.
+// All objects returned here must be treated as read-only.
+type ChaosWorkflowNamespaceLister interface {
+	// List lists all ChaosWorkflows in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ChaosWorkflow, err error)
+	// Get retrieves the ChaosWorkflow from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ChaosWorkflow, error)
+	ChaosWorkflowNamespaceListerExpansion
+}
+
+// chaosWorkflowNamespaceLister implements the ChaosWorkflowNamespaceLister
+// interface.
+type chaosWorkflowNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ChaosWorkflows in the indexer for a given namespace.
+func (s chaosWorkflowNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ChaosWorkflow, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ChaosWorkflow))
+	})
+	return ret, err
+}
+
+// Get retrieves the ChaosWorkflow from the indexer for a given namespace and name.
+func (s chaosWorkflowNamespaceLister) Get(name string) (*v1alpha1.ChaosWorkflow, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("chaosworkflow"), name)
+	}
+	return obj.(*v1alpha1.ChaosWorkflow), nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2023 The Chaos Engineering Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ChaosExperimentListerExpansion allows custom methods to be added to
+// ChaosExperimentLister.
+type ChaosExperimentListerExpansion interface{}
+
+// ChaosExperimentNamespaceListerExpansion allows custom methods to be added to
+// ChaosExperimentNamespaceLister.
+type ChaosExperimentNamespaceListerExpansion interface{}
+
+// ChaosScheduleListerExpansion allows custom methods to be added to
+// ChaosScheduleLister.
+type ChaosScheduleListerExpansion interface{}
+
+// ChaosScheduleNamespaceListerExpansion allows custom methods to be added to
+// ChaosScheduleNamespaceLister.
+type ChaosScheduleNamespaceListerExpansion interface{}
+
+// ChaosWorkflowListerExpansion allows custom methods to be added to
+// ChaosWorkflowLister.
+type ChaosWorkflowListerExpansion interface{}
+
+// ChaosWorkflowNamespaceListerExpansion allows custom methods to be added to
+// ChaosWorkflowNamespaceLister.
+type ChaosWorkflowNamespaceListerExpansion interface{}
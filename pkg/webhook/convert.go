@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	chaosscheme "github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/scheme"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+)
+
+// serveConversion converts the chaos experiments of a conversion review to the desired version
+func (s *Server) serveConversion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var review apiextensionsv1.ConversionReview
+	if err := json.Unmarshal(body, &review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode conversion review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "conversion review has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = convertObjects(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		klog.Errorf("Failed to write conversion response: %v", err)
+	}
+}
+
+// convertObjects converts every object of the request, failing the whole request on the first error
+func convertObjects(request *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
+	response := &apiextensionsv1.ConversionResponse{
+		UID:    request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+
+	for i := range request.Objects {
+		converted, err := convertObject(request.Objects[i].Raw, request.DesiredAPIVersion)
+		if err != nil {
+			klog.Errorf("Failed to convert chaos experiment to %s: %v", request.DesiredAPIVersion, err)
+			return &apiextensionsv1.ConversionResponse{
+				UID: request.UID,
+				Result: metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: err.Error(),
+				},
+			}
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	return response
+}
+
+// convertObject converts a single serialized chaos experiment to the desired API version
+func convertObject(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	desired, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid desired API version %q: %v", desiredAPIVersion, err)
+	}
+
+	obj, gvk, err := deserializer.Decode(raw, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode object: %v", err)
+	}
+	if gvk.GroupVersion() == desired {
+		return raw, nil
+	}
+
+	// The codec converts through the conversion functions registered with the scheme
+	converted, err := runtime.Encode(chaosscheme.Codecs.LegacyCodec(desired), obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s to %s: %v", gvk.GroupVersion(), desired, err)
+	}
+	return converted, nil
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// crdPath is the ChaosExperiment CRD the API server validates experiments against
+const crdPath = "../../deploy/kubernetes/crds/chaosexperiment.yaml"
+
+// loadSchema returns the schema the CRD declares for the given version
+func loadSchema(t *testing.T, version string) *apiextensions.JSONSchemaProps {
+	t.Helper()
+	data, err := os.ReadFile(crdPath)
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := yaml.Unmarshal(data, &crd); err != nil {
+		t.Fatalf("failed to decode CRD: %v", err)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name != version {
+			continue
+		}
+		var schema apiextensions.JSONSchemaProps
+		if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(v.Schema.OpenAPIV3Schema, &schema, nil); err != nil {
+			t.Fatalf("failed to convert schema: %v", err)
+		}
+		return &schema
+	}
+	t.Fatalf("CRD has no version %s", version)
+	return nil
+}
+
+// TestDefaultedExperimentsMatchCRD checks that the API server admits experiments
+// defaulted by the mutating webhook and keeps all of their parameters
+func TestDefaultedExperimentsMatchCRD(t *testing.T) {
+	schema := loadSchema(t, "v1alpha1")
+	validator, _, err := apiservervalidation.NewSchemaValidator(schema)
+	if err != nil {
+		t.Fatalf("failed to create schema validator: %v", err)
+	}
+	structural, err := structuralschema.NewStructural(schema)
+	if err != nil {
+		t.Fatalf("schema is not structural: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		spec       map[string]interface{}
+		parameters map[string]interface{}
+	}{
+		{
+			name: "cpu-hog",
+			spec: map[string]interface{}{"experimentType": "cpu-hog"},
+			parameters: map[string]interface{}{
+				"cpuCores": "1",
+			},
+		},
+		{
+			name: "memory-hog",
+			spec: map[string]interface{}{"experimentType": "memory-hog"},
+			parameters: map[string]interface{}{
+				"memoryMB": "256",
+			},
+		},
+		{
+			name: "network-emulation",
+			spec: map[string]interface{}{
+				"experimentType": "network-emulation",
+				"parameters": map[string]interface{}{
+					"loss":    "10%",
+					"latency": "100ms",
+				},
+			},
+			parameters: map[string]interface{}{
+				"interface": "eth0",
+				"loss":      "10%",
+				"latency":   "100ms",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := map[string]interface{}{
+				"target": map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Pod",
+					"name":       "nginx",
+				},
+				"duration": "30s",
+			}
+			for k, v := range tt.spec {
+				spec[k] = v
+			}
+			raw, err := json.Marshal(map[string]interface{}{
+				"apiVersion": "chaos.engineering/v1alpha1",
+				"kind":       "ChaosExperiment",
+				"metadata":   map[string]interface{}{"name": tt.name, "namespace": "default"},
+				"spec":       spec,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response := mutate(&admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Namespace: "default",
+				Object:    runtime.RawExtension{Raw: raw},
+			})
+			if !response.Allowed {
+				t.Fatalf("mutate denied the experiment: %v", response.Result.Message)
+			}
+			patch, err := jsonpatch.DecodePatch(response.Patch)
+			if err != nil {
+				t.Fatalf("invalid patch: %v", err)
+			}
+			defaulted, err := patch.Apply(raw)
+			if err != nil {
+				t.Fatalf("failed to apply patch: %v", err)
+			}
+
+			var obj map[string]interface{}
+			if err := json.Unmarshal(defaulted, &obj); err != nil {
+				t.Fatal(err)
+			}
+			if errs := apiservervalidation.ValidateCustomResource(nil, obj, validator); len(errs) > 0 {
+				t.Fatalf("CRD rejects the defaulted experiment: %v", errs.ToAggregate())
+			}
+			pruning.Prune(obj, structural, true)
+			parameters := obj["spec"].(map[string]interface{})["parameters"]
+			if !reflect.DeepEqual(parameters, tt.parameters) {
+				t.Errorf("got parameters %v after pruning, want %v", parameters, tt.parameters)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/experiments"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// patchOperation is a single JSON patch operation
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// mutate fills in the defaults of a chaos experiment so that the object records
+// exactly what is injected
+func mutate(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	experiment, err := decodeExperiment(request.Object.Raw)
+	if err != nil {
+		return denied(metav1.StatusReasonBadRequest, err.Error())
+	}
+	if experiment.DeletionTimestamp != nil {
+		return allowed()
+	}
+
+	namespace := experiment.Namespace
+	if namespace == "" {
+		namespace = request.Namespace
+	}
+
+	patch := defaultingPatch(experiment, namespace)
+	if len(patch) == 0 {
+		return allowed()
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return denied(metav1.StatusReasonInternalError, fmt.Sprintf("failed to encode patch: %v", err))
+	}
+	patchType := admissionv1.PatchTypeJSONPatch
+	response := allowed()
+	response.Patch = patchBytes
+	response.PatchType = &patchType
+	return response
+}
+
+// defaultingPatch returns the JSON patch that sets the unset parameters of the
+// experiment to their defaults and targets its own namespace when none is given
+func defaultingPatch(experiment *v1alpha1.ChaosExperiment, namespace string) []patchOperation {
+	var patch []patchOperation
+
+	if experiment.Spec.Target.Namespace == "" && namespace != "" {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/target/namespace", Value: namespace})
+	}
+
+	defaults := experiments.DefaultParameters(experiment.Spec.ExperimentType)
+	if len(defaults) == 0 {
+		return patch
+	}
+	if experiment.Spec.Parameters == nil {
+		return append(patch, patchOperation{Op: "add", Path: "/spec/parameters", Value: defaults})
+	}
+
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		if _, ok := experiment.Spec.Parameters[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/parameters/" + escapePointer(name), Value: defaults[name]})
+	}
+	return patch
+}
+
+// escapePointer escapes a key for use in a JSON pointer
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	chaosscheme "github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/scheme"
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	"github.com/chaos-engineering/controller/pkg/chaos/validation"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// deserializer decodes chaos experiments of any version registered with the chaos scheme
+var deserializer = chaosscheme.Codecs.UniversalDeserializer()
+
+// Server serves the admission and conversion webhooks for chaos experiments
+type Server struct {
+	mux *http.ServeMux
+}
+
+// NewServer creates a new webhook server
+func NewServer() *Server {
+	s := &Server{
+		mux: http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/mutate", s.serveAdmission(mutate))
+	s.mux.HandleFunc("/validate", s.serveAdmission(validate))
+	s.mux.HandleFunc("/convert", s.serveConversion)
+	s.mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// admitFunc reviews a single admission request
+type admitFunc func(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse
+
+// serveAdmission decodes an admission review, passes its request to admit and writes back the response
+func (s *Server) serveAdmission(admit admitFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		var review admissionv1.AdmissionReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode admission review: %v", err), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "admission review has no request", http.StatusBadRequest)
+			return
+		}
+
+		response := admit(review.Request)
+		response.UID = review.Request.UID
+		review.Response = response
+		review.Request = nil
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(review); err != nil {
+			klog.Errorf("Failed to write admission response: %v", err)
+		}
+	}
+}
+
+// validate rejects chaos experiments with an invalid spec
+func validate(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	experiment, err := decodeExperiment(request.Object.Raw)
+	if err != nil {
+		return denied(metav1.StatusReasonBadRequest, err.Error())
+	}
+
+	// Experiments that are being deleted must be able to drop their finalizer,
+	// and updates that leave the spec alone cannot make it any less valid
+	if experiment.DeletionTimestamp != nil {
+		return allowed()
+	}
+	if request.Operation == admissionv1.Update && len(request.OldObject.Raw) > 0 {
+		old, err := decodeExperiment(request.OldObject.Raw)
+		if err == nil && equality.Semantic.DeepEqual(old.Spec, experiment.Spec) {
+			return allowed()
+		}
+	}
+
+	if errs := validation.ValidateChaosExperiment(experiment); len(errs) > 0 {
+		klog.V(2).Infof("Rejecting chaos experiment %s/%s: %v", request.Namespace, request.Name, errs.ToAggregate())
+		return denied(metav1.StatusReasonInvalid, errs.ToAggregate().Error())
+	}
+	return allowed()
+}
+
+// decodeExperiment decodes a v1alpha1 chaos experiment, the version the admission webhooks are registered for
+func decodeExperiment(raw []byte) (*v1alpha1.ChaosExperiment, error) {
+	obj, _, err := deserializer.Decode(raw, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode chaos experiment: %v", err)
+	}
+	experiment, ok := obj.(*v1alpha1.ChaosExperiment)
+	if !ok {
+		return nil, fmt.Errorf("expected a %s ChaosExperiment, got %T", v1alpha1.SchemeGroupVersion, obj)
+	}
+	return experiment, nil
+}
+
+func allowed() *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// denied rejects the request with the status code the API server uses for the reason
+func denied(reason metav1.StatusReason, message string) *admissionv1.AdmissionResponse {
+	code := int32(http.StatusForbidden)
+	switch reason {
+	case metav1.StatusReasonBadRequest:
+		code = http.StatusBadRequest
+	case metav1.StatusReasonInvalid:
+		code = http.StatusUnprocessableEntity
+	case metav1.StatusReasonInternalError:
+		code = http.StatusInternalServerError
+	}
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  reason,
+			Code:    code,
+		},
+	}
+}
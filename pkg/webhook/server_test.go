@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chaos-engineering/controller/pkg/chaos/apis/chaos/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newExperiment returns a valid cpu-hog experiment without defaults
+func newExperiment() *v1alpha1.ChaosExperiment {
+	return &v1alpha1.ChaosExperiment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ChaosExperiment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cpu-hog", Namespace: "default"},
+		Spec: v1alpha1.ChaosExperimentSpec{
+			Target:         v1alpha1.TargetResource{APIVersion: "v1", Kind: "Pod", Name: "nginx"},
+			ExperimentType: "cpu-hog",
+			Duration:       "1m",
+		},
+	}
+}
+
+func rawExperiment(t *testing.T, experiment *v1alpha1.ChaosExperiment) runtime.RawExtension {
+	t.Helper()
+	raw, err := json.Marshal(experiment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return runtime.RawExtension{Raw: raw}
+}
+
+// admit posts an admission review with the request to the path of the webhook
+// server and returns the response
+func admit(t *testing.T, path string, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	t.Helper()
+	server := httptest.NewServer(NewServer())
+	defer server.Close()
+
+	request.UID = types.UID("review-uid")
+	body, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: admissionv1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+		Request:  request,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(server.URL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		t.Fatalf("failed to decode admission review: %v", err)
+	}
+	if review.Response == nil {
+		t.Fatalf("admission review has no response")
+	}
+	if review.Response.UID != request.UID {
+		t.Errorf("got UID %q, want %q", review.Response.UID, request.UID)
+	}
+	return review.Response
+}
+
+func TestMutateDefaultsCreate(t *testing.T) {
+	response := admit(t, "/mutate", &admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Namespace: "default",
+		Object:    rawExperiment(t, newExperiment()),
+	})
+
+	if !response.Allowed {
+		t.Fatalf("experiment was denied: %v", response.Result.Message)
+	}
+	if response.PatchType == nil || *response.PatchType != admissionv1.PatchTypeJSONPatch {
+		t.Fatalf("got patch type %v, want %s", response.PatchType, admissionv1.PatchTypeJSONPatch)
+	}
+	var patch []patchOperation
+	if err := json.Unmarshal(response.Patch, &patch); err != nil {
+		t.Fatalf("invalid patch: %v", err)
+	}
+	want := []patchOperation{
+		{Op: "add", Path: "/spec/target/namespace", Value: "default"},
+		{Op: "add", Path: "/spec/parameters", Value: map[string]interface{}{"cpuCores": "1"}},
+	}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("got patch %v, want %v", patch, want)
+	}
+}
+
+func TestValidateDeniesInvalidSpec(t *testing.T) {
+	experiment := newExperiment()
+	experiment.Spec.Duration = "forever"
+
+	response := admit(t, "/validate", &admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Namespace: "default",
+		Object:    rawExperiment(t, experiment),
+	})
+
+	if response.Allowed {
+		t.Fatalf("invalid experiment was allowed")
+	}
+	if response.Result.Reason != metav1.StatusReasonInvalid || response.Result.Code != http.StatusUnprocessableEntity {
+		t.Errorf("got reason %s and code %d, want %s and %d", response.Result.Reason, response.Result.Code, metav1.StatusReasonInvalid, http.StatusUnprocessableEntity)
+	}
+	if !strings.Contains(response.Result.Message, "spec.duration") {
+		t.Errorf("message %q does not name the invalid field", response.Result.Message)
+	}
+}
+
+func TestValidateSkipsUnchangedSpec(t *testing.T) {
+	// Experiments created while the webhook was down may already be invalid, which
+	// must not block updates such as status changes or removing the finalizer
+	old := newExperiment()
+	old.Spec.Duration = "forever"
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"team": "checkout"}
+
+	response := admit(t, "/validate", &admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		Namespace: "default",
+		Object:    rawExperiment(t, updated),
+		OldObject: rawExperiment(t, old),
+	})
+	if !response.Allowed {
+		t.Errorf("update with an unchanged spec was denied: %v", response.Result.Message)
+	}
+
+	updated.Spec.Duration = "never"
+	response = admit(t, "/validate", &admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		Namespace: "default",
+		Object:    rawExperiment(t, updated),
+		OldObject: rawExperiment(t, old),
+	})
+	if response.Allowed {
+		t.Errorf("update to an invalid spec was allowed")
+	}
+}
+
+func TestDeleteInProgressIsAllowed(t *testing.T) {
+	experiment := newExperiment()
+	experiment.Spec.Duration = "forever"
+	now := metav1.Now()
+	experiment.DeletionTimestamp = &now
+	experiment.Finalizers = []string{v1alpha1.ExperimentFinalizer}
+
+	for _, path := range []string{"/mutate", "/validate"} {
+		t.Run(path, func(t *testing.T) {
+			response := admit(t, path, &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Namespace: "default",
+				Object:    rawExperiment(t, experiment),
+			})
+			if !response.Allowed {
+				t.Errorf("experiment being deleted was denied: %v", response.Result.Message)
+			}
+			if len(response.Patch) > 0 {
+				t.Errorf("experiment being deleted was patched: %s", response.Patch)
+			}
+		})
+	}
+}